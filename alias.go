@@ -19,3 +19,6 @@ func (a *AliasIterator) Alias() (*Alias, error) {
 	rcv := &Alias{}
 	return rcv, a.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (a *AliasIterator) Iter() *Iter[*Alias] { return newIter(a, a.Alias) }
@@ -2,14 +2,15 @@ package authorization
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/jacklaaa89/trakt"
 )
 
-// client the authorization client which is used for requests.
-type client struct{ b trakt.BaseClient }
+// Client the authorization client which is used for requests.
+type Client struct{ b trakt.PathClient }
 
 // newDeviceCodeParams request structure to generate a new device code.
 type newDeviceCodeParams struct {
@@ -29,10 +30,10 @@ func NewCode(params *trakt.BasicParams) (*trakt.DeviceCode, error) {
 // NewCode Generates new codes to start the device authentication process. The device_code and interval
 // will be used later to poll for the access_token. The user_code and verification_url should be presented
 // to the user as mentioned in the flow steps above.
-func (c *client) NewCode(params *trakt.BasicParams) (*trakt.DeviceCode, error) {
+func (c *Client) NewCode(params *trakt.BasicParams) (*trakt.DeviceCode, error) {
 	d := &trakt.DeviceCode{}
 	p := &newDeviceCodeParams{params, c.b.Key()}
-	err := c.b.Call(http.MethodPost, "/oauth/device/code", p, &d)
+	err := c.b.CallPath(http.MethodPost, trakt.NewPath("/oauth/device/code"), p, &d)
 	return d, err
 }
 
@@ -99,7 +100,7 @@ func Poll(params *trakt.PollCodeParams) (*trakt.Token, error) {
 //
 // if you require more control over when your app blocks for the result, use PollAsync which returns a
 // channel that the result is pushed to.
-func (c *client) Poll(params *trakt.PollCodeParams) (*trakt.Token, error) {
+func (c *Client) Poll(params *trakt.PollCodeParams) (*trakt.Token, error) {
 	r := <-c.PollAsync(params)
 	return r.Token, r.Err
 }
@@ -128,7 +129,7 @@ func PollAsync(params *trakt.PollCodeParams) <-chan *trakt.PollResult {
 //
 // This function does not block but instead returns a read-only channel which will have the result of polling
 // for the result once it is available.
-func (c *client) PollAsync(params *trakt.PollCodeParams) <-chan *trakt.PollResult {
+func (c *Client) PollAsync(params *trakt.PollCodeParams) <-chan *trakt.PollResult {
 	cCtx := params.Context
 	if cCtx == nil {
 		cCtx = context.Background()
@@ -147,8 +148,7 @@ func (c *client) PollAsync(params *trakt.PollCodeParams) <-chan *trakt.PollResul
 			return
 		}
 
-		traktError, ok := err.(*trakt.Error)
-		if !ok || !canContinuePolling(traktError.Code) {
+		if !canContinuePolling(err) {
 			ch <- &trakt.PollResult{Err: err}
 			return
 		}
@@ -172,8 +172,7 @@ func (c *client) PollAsync(params *trakt.PollCodeParams) <-chan *trakt.PollResul
 				// determine if we can continue to poll
 				// there are certain error codes which mean we can
 				// continue.
-				traktError, ok := err.(*trakt.Error)
-				if !ok || !canContinuePolling(traktError.Code) {
+				if !canContinuePolling(err) {
 					ch <- &trakt.PollResult{Err: err}
 					return
 				}
@@ -191,10 +190,10 @@ func (c *client) PollAsync(params *trakt.PollCodeParams) <-chan *trakt.PollResul
 
 // poll performs a HTTP request to poll for the status of authorization on a device code.
 // This function should be called on the interval defined in the DeviceCode when it was generated.
-func (c *client) poll(params *trakt.PollCodeParams) (*trakt.Token, error) {
+func (c *Client) poll(params *trakt.PollCodeParams) (*trakt.Token, error) {
 	t := &trakt.Token{}
 	p := &wrappedPollCodeParams{params, c.b.Key()}
-	err := c.b.Call(http.MethodPost, "/oauth/device/token", p, t)
+	err := c.b.CallPath(http.MethodPost, trakt.NewPath("/oauth/device/token"), p, t)
 	return t, err
 }
 
@@ -206,9 +205,13 @@ func withContext(ctx context.Context, w *trakt.PollCodeParams) *trakt.PollCodePa
 	return &cp
 }
 
-// canContinuePolling determines if the status code returned means that we can
+// canContinuePolling determines if the error returned means that we can
 // continue polling.
-func canContinuePolling(e trakt.ErrorCode) bool { return e == trakt.ErrorCodePendingDeviceCode }
+func canContinuePolling(err error) bool { return errors.Is(err, trakt.ErrPendingDeviceCode) }
+
+// NewClient initialises a new authorization client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
 
 // getC returns a copy of a authorization client with the currently defined backend attached.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -50,7 +50,7 @@ func AuthorizeURL(params *trakt.AuthorizationURLParams) (string, error) {
 // complete. You can use "urn:ietf:wg:oauth:2.0:oob" for device authentication.
 // The state is a random unique string which will be returned to the Redirect URI. This can be compared against
 // to reduce the risk of "Man-In-The-Middle" attacks. See: https://en.wikipedia.org/wiki/Man-in-the-middle_attack
-func (c *client) AuthorizeURL(params *trakt.AuthorizationURLParams) (string, error) {
+func (c *Client) AuthorizeURL(params *trakt.AuthorizationURLParams) (string, error) {
 	if params == nil {
 		return "", errors.New("params cannot be nil")
 	}
@@ -101,10 +101,10 @@ func ExchangeCode(params *trakt.ExchangeCodeParams) (*trakt.Token, error) {
 // without asking the user to re-authenticate.
 // This function requires the same RedirectURI which was sent in the initial request to get the code
 // and also the client Secret which is also assigned to you when you create an application on Trakt.
-func (c *client) ExchangeCode(params *trakt.ExchangeCodeParams) (*trakt.Token, error) {
+func (c *Client) ExchangeCode(params *trakt.ExchangeCodeParams) (*trakt.Token, error) {
 	t := &trakt.Token{}
 	p := &wrappedExchangeCodeParams{params, genericTokenParameters{c.b.Key(), authorizationCode}}
-	err := c.b.Call(http.MethodPost, `/oauth/token`, p, t)
+	err := c.b.CallPath(http.MethodPost, trakt.NewPath(`/oauth/token`), p, t)
 	return t, err
 }
 
@@ -127,10 +127,10 @@ func RefreshToken(params *trakt.RefreshTokenParams) (*trakt.Token, error) {
 // The Access Token is valid for 3 months before it needs to be refreshed again.
 // This function requires the same RedirectURI which was sent in the initial request to get the code
 // and also the client Secret which is also assigned to you when you create an application on Trakt.
-func (c *client) RefreshToken(params *trakt.RefreshTokenParams) (*trakt.Token, error) {
+func (c *Client) RefreshToken(params *trakt.RefreshTokenParams) (*trakt.Token, error) {
 	t := &trakt.Token{}
 	p := &wrappedRefreshTokenParams{params, genericTokenParameters{c.b.Key(), refreshToken}}
-	err := c.b.Call(http.MethodPost, `/oauth/token`, p, t)
+	err := c.b.CallPath(http.MethodPost, trakt.NewPath(`/oauth/token`), p, t)
 	return t, err
 }
 
@@ -154,7 +154,7 @@ func RevokeToken(params *trakt.RevokeTokenParams) error {
 // This is not required, but might improve the user experience so the user doesn't have an
 // unused app connection hanging around.
 // This function requires the client Secret which is also assigned to you when you create an application on Trakt.
-func (c *client) RevokeToken(params *trakt.RevokeTokenParams) error {
+func (c *Client) RevokeToken(params *trakt.RevokeTokenParams) error {
 	p := &wrappedRevokeTokenParams{params, c.b.Key()}
-	return c.b.Call(http.MethodPost, `/oauth/revoke`, p, nil)
+	return c.b.CallPath(http.MethodPost, trakt.NewPath(`/oauth/revoke`), p, nil)
 }
@@ -0,0 +1,242 @@
+package trakt
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultBreakerFailureRatio the default ratio of failed requests which opens the circuit.
+	defaultBreakerFailureRatio = 0.5
+	// defaultBreakerMinRequests the default amount of requests required before the circuit can open.
+	defaultBreakerMinRequests = 10
+	// defaultBreakerWindow the default period failures are counted over.
+	defaultBreakerWindow = time.Minute
+	// defaultBreakerOpenTimeout the default amount of time the circuit stays open.
+	defaultBreakerOpenTimeout = 30 * time.Second
+	// defaultBreakerHalfOpenRequests the default amount of probes allowed while half-open.
+	defaultBreakerHalfOpenRequests = 1
+)
+
+// CircuitState the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed requests are performed as normal.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen requests fail fast without being performed.
+	CircuitOpen
+	// CircuitHalfOpen a limited amount of requests are performed to probe
+	// whether trakt has recovered.
+	CircuitHalfOpen
+)
+
+// String implements fmt.Stringer interface.
+func (c CircuitState) String() string {
+	switch c {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	}
+
+	return "closed"
+}
+
+// CircuitBreaker configures a circuit breaker which stops requests being performed while trakt
+// is failing. Requests which fail to reach trakt or fail with a server error (including the
+// cloudflare errors trakt returns during an outage) count as failures.
+//
+// Once the ratio of failed requests within the window reaches FailureRatio the circuit opens and
+// every request fails fast with ErrCircuitOpen. After OpenTimeout the circuit half-opens and a
+// limited amount of requests are allowed to probe whether trakt has recovered, the circuit closes
+// if a probe succeeds and opens again if a probe fails.
+type CircuitBreaker struct {
+	// FailureRatio the ratio of failed requests within the window which opens the circuit.
+	//
+	// Defaults to 0.5.
+	FailureRatio float64
+	// MinRequests the minimum amount of requests within the window before the circuit can open.
+	//
+	// Defaults to 10.
+	MinRequests int
+	// Window the period which failures are counted over.
+	//
+	// Defaults to 1 minute.
+	Window time.Duration
+	// OpenTimeout the amount of time the circuit stays open before half-opening.
+	//
+	// Defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenRequests the amount of concurrent requests allowed while the circuit is half-open.
+	//
+	// Defaults to 1.
+	HalfOpenRequests int
+}
+
+// CircuitObserver can be implemented by an Observer to be notified when the state of the
+// circuit breaker changes.
+type CircuitObserver interface {
+	// CircuitStateChanged is called when the circuit changes from one state to another.
+	CircuitStateChanged(from, to CircuitState)
+}
+
+// breaker is the internal implementation of a CircuitBreaker.
+type breaker struct {
+	mu sync.Mutex
+
+	// cfg the configuration with the defaults applied.
+	cfg CircuitBreaker
+	// onChange is called while holding the lock whenever the state changes.
+	onChange func(from, to CircuitState)
+
+	state CircuitState
+	// windowStart the time the current window started.
+	windowStart time.Time
+	// requests and failures the amount of requests and failures in the current window.
+	requests, failures int
+	// openedAt the time the circuit was last opened.
+	openedAt time.Time
+	// probes the amount of in-flight probes while half-open.
+	probes int
+	// generation is incremented on every state change, so that the outcome of a request
+	// which was allowed in a previous state is ignored.
+	generation uint64
+}
+
+// newBreaker initialises a new breaker from the supplied configuration, a nil breaker is
+// returned if no configuration is supplied.
+func newBreaker(c *CircuitBreaker, onChange func(from, to CircuitState)) *breaker {
+	if c == nil {
+		return nil
+	}
+
+	cfg := *c
+	if cfg.FailureRatio <= 0 {
+		cfg.FailureRatio = defaultBreakerFailureRatio
+	}
+
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = defaultBreakerMinRequests
+	}
+
+	if cfg.Window <= 0 {
+		cfg.Window = defaultBreakerWindow
+	}
+
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultBreakerOpenTimeout
+	}
+
+	if cfg.HalfOpenRequests <= 0 {
+		cfg.HalfOpenRequests = defaultBreakerHalfOpenRequests
+	}
+
+	return &breaker{cfg: cfg, onChange: onChange, windowStart: time.Now()}
+}
+
+// allow determines if a request can be performed, returning the generation the request was
+// allowed in which has to be supplied when recording its outcome. If the request cannot be
+// performed, the amount of time until the circuit half-opens is returned.
+func (b *breaker) allow(now time.Time) (uint64, bool, time.Duration) {
+	if b == nil {
+		return 0, true, 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen {
+		wait := b.openedAt.Add(b.cfg.OpenTimeout).Sub(now)
+		if wait > 0 {
+			return 0, false, wait
+		}
+
+		b.setState(CircuitHalfOpen, now)
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.probes >= b.cfg.HalfOpenRequests {
+			return 0, false, 0
+		}
+
+		b.probes++
+	}
+
+	return b.generation, true, 0
+}
+
+// done records the outcome of a request which was allowed. A request which was cancelled
+// by the caller is neither a success nor a failure.
+func (b *breaker) done(ctx context.Context, generation uint64, err error, now time.Time) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	failed := err != nil && isNetworkFailure(ctx, err)
+	cancelled := err != nil && !failed && ctx.Err() != nil
+
+	if b.state == CircuitHalfOpen {
+		b.probes--
+		switch {
+		case cancelled:
+		case failed:
+			b.setState(CircuitOpen, now)
+		default:
+			b.setState(CircuitClosed, now)
+		}
+
+		return
+	}
+
+	if cancelled {
+		return
+	}
+
+	if now.Sub(b.windowStart) > b.cfg.Window {
+		b.windowStart, b.requests, b.failures = now, 0, 0
+	}
+
+	b.requests++
+	if failed {
+		b.failures++
+	}
+
+	if b.requests >= b.cfg.MinRequests && float64(b.failures)/float64(b.requests) >= b.cfg.FailureRatio {
+		b.setState(CircuitOpen, now)
+	}
+}
+
+// setState transitions the circuit into a new state, resetting the counts.
+func (b *breaker) setState(state CircuitState, now time.Time) {
+	from := b.state
+	b.state, b.probes = state, 0
+	b.generation++
+	b.windowStart, b.requests, b.failures = now, 0, 0
+	if state == CircuitOpen {
+		b.openedAt = now
+	}
+
+	if b.onChange != nil {
+		b.onChange(from, state)
+	}
+}
+
+// newCircuitOpenError generates the error returned when a request is not performed
+// because the circuit is open.
+func newCircuitOpenError(resource string, wait time.Duration) *Error {
+	return &Error{
+		Resource:   resource,
+		Code:       ErrorCodeCircuitOpen,
+		Body:       "circuit breaker is open, trakt is failing",
+		RetryAfter: wait,
+	}
+}
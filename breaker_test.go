@@ -0,0 +1,316 @@
+package trakt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// breakerConfig the configuration used by the breaker tests.
+var breakerConfig = &CircuitBreaker{FailureRatio: 0.5, MinRequests: 2, Window: time.Minute, OpenTimeout: 10 * time.Second}
+
+// fakeClock a clock which only moves forward when advanced.
+type fakeClock struct{ now time.Time }
+
+// advance moves the clock forward by d, returning the new time.
+func (c *fakeClock) advance(d time.Duration) time.Time {
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+// outcome the outcome of a request performed by the breaker tests.
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	outcomeCancelled
+)
+
+// breakerStep a single request performed against a breaker.
+type breakerStep struct {
+	// advance the amount of time to move the clock forward before performing the request.
+	advance time.Duration
+	// outcome the outcome of the request, if it is allowed.
+	outcome outcome
+	// denied whether the request is expected to fail fast.
+	denied bool
+	// state the expected state of the circuit after the request.
+	state CircuitState
+}
+
+// perform performs the step against the breaker.
+func (s breakerStep) perform(t *testing.T, b *breaker, clock *fakeClock) {
+	t.Helper()
+
+	now := clock.advance(s.advance)
+	generation, allowed, _ := b.allow(now)
+	if allowed == s.denied {
+		t.Fatalf("expected the request to be allowed to be %v, got %v", !s.denied, allowed)
+	}
+
+	if allowed {
+		ctx, err := context.Background(), error(nil)
+		switch s.outcome {
+		case outcomeFailure:
+			err = &Error{HTTPStatusCode: http.StatusInternalServerError}
+		case outcomeCancelled:
+			var cancelFunc context.CancelFunc
+			ctx, cancelFunc = context.WithCancel(ctx)
+			cancelFunc()
+			err = ctx.Err()
+		}
+
+		b.done(ctx, generation, err, now)
+	}
+
+	if b.state != s.state {
+		t.Fatalf("expected the circuit to be %v, got %v", s.state, b.state)
+	}
+}
+
+// newTestBreaker generates a breaker using the supplied config and a clock, recording every
+// state the circuit changes to in transitions.
+func newTestBreaker(c *CircuitBreaker, transitions *[]CircuitState) (*breaker, *fakeClock) {
+	b := newBreaker(c, func(_, to CircuitState) { *transitions = append(*transitions, to) })
+	return b, &fakeClock{now: b.windowStart}
+}
+
+func TestBreaker(t *testing.T) {
+	tests := []struct {
+		name        string
+		steps       []breakerStep
+		transitions []CircuitState
+	}{
+		{
+			name:  "closed below the minimum requests",
+			steps: []breakerStep{{outcome: outcomeFailure, state: CircuitClosed}},
+		},
+		{
+			name: "opens at the failure ratio",
+			steps: []breakerStep{
+				{outcome: outcomeSuccess, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitOpen},
+			},
+			transitions: []CircuitState{CircuitOpen},
+		},
+		{
+			name: "closed below the failure ratio",
+			steps: []breakerStep{
+				{outcome: outcomeSuccess, state: CircuitClosed},
+				{outcome: outcomeSuccess, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitOpen},
+			},
+			transitions: []CircuitState{CircuitOpen},
+		},
+		{
+			name: "counts reset after the window",
+			steps: []breakerStep{
+				{outcome: outcomeFailure, state: CircuitClosed},
+				{advance: 2 * time.Minute, outcome: outcomeSuccess, state: CircuitClosed},
+				{outcome: outcomeSuccess, state: CircuitClosed},
+			},
+		},
+		{
+			name: "cancelled requests are ignored",
+			steps: []breakerStep{
+				{outcome: outcomeFailure, state: CircuitClosed},
+				{outcome: outcomeCancelled, state: CircuitClosed},
+				{outcome: outcomeCancelled, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitOpen},
+			},
+			transitions: []CircuitState{CircuitOpen},
+		},
+		{
+			name: "fails fast while open",
+			steps: []breakerStep{
+				{outcome: outcomeFailure, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitOpen},
+				{denied: true, state: CircuitOpen},
+				{advance: 9 * time.Second, denied: true, state: CircuitOpen},
+			},
+			transitions: []CircuitState{CircuitOpen},
+		},
+		{
+			name: "half-open probe closes",
+			steps: []breakerStep{
+				{outcome: outcomeFailure, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitOpen},
+				{advance: 10 * time.Second, outcome: outcomeSuccess, state: CircuitClosed},
+			},
+			transitions: []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed},
+		},
+		{
+			name: "half-open probe opens",
+			steps: []breakerStep{
+				{outcome: outcomeFailure, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitOpen},
+				{advance: 10 * time.Second, outcome: outcomeFailure, state: CircuitOpen},
+				{advance: 9 * time.Second, denied: true, state: CircuitOpen},
+				{advance: time.Second, outcome: outcomeSuccess, state: CircuitClosed},
+			},
+			transitions: []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen, CircuitHalfOpen, CircuitClosed},
+		},
+		{
+			name: "cancelled probe stays half-open",
+			steps: []breakerStep{
+				{outcome: outcomeFailure, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitOpen},
+				{advance: 10 * time.Second, outcome: outcomeCancelled, state: CircuitHalfOpen},
+				{outcome: outcomeSuccess, state: CircuitClosed},
+			},
+			transitions: []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed},
+		},
+		{
+			name: "counts reset once closed",
+			steps: []breakerStep{
+				{outcome: outcomeFailure, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitOpen},
+				{advance: 10 * time.Second, outcome: outcomeSuccess, state: CircuitClosed},
+				{outcome: outcomeFailure, state: CircuitClosed},
+			},
+			transitions: []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var transitions []CircuitState
+			b, clock := newTestBreaker(breakerConfig, &transitions)
+			for _, s := range tt.steps {
+				s.perform(t, b, clock)
+			}
+
+			if len(transitions) != len(tt.transitions) {
+				t.Fatalf("expected transitions %v, got %v", tt.transitions, transitions)
+			}
+
+			for i := range transitions {
+				if transitions[i] != tt.transitions[i] {
+					t.Errorf("expected transitions %v, got %v", tt.transitions, transitions)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestBreaker_OpenWait(t *testing.T) {
+	var transitions []CircuitState
+	b, clock := newTestBreaker(breakerConfig, &transitions)
+	breakerStep{outcome: outcomeFailure, state: CircuitClosed}.perform(t, b, clock)
+	breakerStep{outcome: outcomeFailure, state: CircuitOpen}.perform(t, b, clock)
+
+	_, allowed, wait := b.allow(clock.advance(4 * time.Second))
+	if allowed || wait != 6*time.Second {
+		t.Errorf("expected to wait %v, got %v (allowed %v)", 6*time.Second, wait, allowed)
+	}
+}
+
+func TestBreaker_StaleGeneration(t *testing.T) {
+	var transitions []CircuitState
+	b, clock := newTestBreaker(breakerConfig, &transitions)
+
+	// a request allowed while closed which completes after the circuit has opened.
+	stale, _, _ := b.allow(clock.now)
+	breakerStep{outcome: outcomeFailure, state: CircuitClosed}.perform(t, b, clock)
+	breakerStep{outcome: outcomeFailure, state: CircuitOpen}.perform(t, b, clock)
+
+	probe, allowed, _ := b.allow(clock.advance(10 * time.Second))
+	if !allowed {
+		t.Fatal("expected the probe to be allowed")
+	}
+
+	// the stale success neither closes the circuit nor releases the probe.
+	b.done(context.Background(), stale, nil, clock.now)
+	if b.state != CircuitHalfOpen {
+		t.Fatalf("expected the circuit to be %v, got %v", CircuitHalfOpen, b.state)
+	}
+
+	if _, allowed, _ := b.allow(clock.now); allowed {
+		t.Fatal("expected a second probe to be denied")
+	}
+
+	b.done(context.Background(), probe, nil, clock.now)
+	if b.state != CircuitClosed {
+		t.Errorf("expected the circuit to be %v, got %v", CircuitClosed, b.state)
+	}
+}
+
+func TestBreaker_ConcurrentProbes(t *testing.T) {
+	cfg := *breakerConfig
+	cfg.HalfOpenRequests = 2
+
+	var transitions []CircuitState
+	b, clock := newTestBreaker(&cfg, &transitions)
+	breakerStep{outcome: outcomeFailure, state: CircuitClosed}.perform(t, b, clock)
+	breakerStep{outcome: outcomeFailure, state: CircuitOpen}.perform(t, b, clock)
+
+	now := clock.advance(10 * time.Second)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var probes []uint64
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if generation, allowed, _ := b.allow(now); allowed {
+				mu.Lock()
+				probes = append(probes, generation)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(probes) != cfg.HalfOpenRequests {
+		t.Fatalf("expected %d probes to be allowed, got %d", cfg.HalfOpenRequests, len(probes))
+	}
+
+	// the first probe to fail opens the circuit, the outcome of the other is from a previous generation.
+	b.done(context.Background(), probes[0], &Error{HTTPStatusCode: http.StatusBadGateway}, now)
+	b.done(context.Background(), probes[1], nil, now)
+	if b.state != CircuitOpen {
+		t.Errorf("expected the circuit to be %v, got %v", CircuitOpen, b.state)
+	}
+}
+
+func TestBackend_CircuitOpen(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	b := NewBackend(&BackendConfig{
+		URL:            srv.URL,
+		GetRateLimit:   NoRateLimit,
+		CircuitBreaker: &CircuitBreaker{MinRequests: 1, OpenTimeout: time.Minute},
+	})
+
+	if err := b.Call(http.MethodGet, "/movies/trending", "key", &BasicParams{}, nil); !errors.Is(err, ErrServerError) {
+		t.Fatalf("expected %v, got %v", ErrServerError, err)
+	}
+
+	err := b.Call(http.MethodGet, "/movies/trending", "key", &BasicParams{}, nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected %v, got %v", ErrCircuitOpen, err)
+	}
+
+	var traktErr *Error
+	if !errors.As(err, &traktErr) || traktErr.RetryAfter <= 0 {
+		t.Errorf("expected the time until the circuit half-opens, got %v", traktErr.RetryAfter)
+	}
+
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Errorf("expected 1 request to reach trakt, got %d", n)
+	}
+}
@@ -0,0 +1,339 @@
+package trakt
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCacheTTL the default amount of time a cached response is considered
+	// fresh if the response does not define its own max-age.
+	defaultCacheTTL = 5 * time.Minute
+	// defaultMemoryCacheSize the default amount of entries an in-memory cache can hold.
+	defaultMemoryCacheSize = 1000
+
+	// cache specific request / response headers.
+	etagHeader         = "ETag"
+	ifNoneMatchHeader  = "If-None-Match"
+	cacheControlHeader = "Cache-Control"
+)
+
+// Cache is used to store the responses from GET requests so that they do not have to
+// be re-fetched on every call. Entries are retained after they have expired so that they
+// can be revalidated using their ETag or served if the network fails.
+//
+// Implementations must be safe to use across multiple go-routines.
+type Cache interface {
+	// Get retrieves a cached response by its key.
+	Get(key string) (*CachedResponse, bool)
+	// Set stores a response using the supplied key.
+	Set(key string, r *CachedResponse)
+	// Delete removes a response by its key.
+	Delete(key string)
+}
+
+// CachedResponse is a response which has been stored in a Cache.
+type CachedResponse struct {
+	// StatusCode the status code of the original response.
+	StatusCode int `json:"status"`
+	// Header the headers from the original response, this includes
+	// the pagination headers for paginated results.
+	Header http.Header `json:"header"`
+	// Body the raw response body.
+	Body []byte `json:"body"`
+	// ETag the entity tag of the response, used to revalidate the response
+	// once it has expired.
+	ETag string `json:"etag,omitempty"`
+	// StoredAt the time the response was stored.
+	StoredAt time.Time `json:"stored_at"`
+	// ExpiresAt the time the response is no longer considered fresh.
+	ExpiresAt time.Time `json:"expires_at"`
+	// MustRevalidate whether the response must not be served once it has expired
+	// without being revalidated, even if the network fails.
+	MustRevalidate bool `json:"must_revalidate,omitempty"`
+}
+
+// Fresh determines if the response can still be used without revalidation.
+func (c *CachedResponse) Fresh(now time.Time) bool { return now.Before(c.ExpiresAt) }
+
+// response generates a http.Response from the cached response for the supplied request.
+func (c *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     strconv.Itoa(c.StatusCode) + " " + http.StatusText(c.StatusCode),
+		StatusCode: c.StatusCode,
+		Header:     c.Header.Clone(),
+		Request:    req,
+	}
+}
+
+// privateCache is implemented by caches which are only accessible to the current process,
+// responses marked as private are only stored in these caches.
+type privateCache interface {
+	// private returns whether the cache is only accessible to the current process.
+	private() bool
+}
+
+// cacheKey generates the key for a request, requests are keyed by their URL, the
+// client_id and the OAuth token used so that responses are never shared between
+// applications or users.
+func cacheKey(req *http.Request) string {
+	key := req.Method + " " + req.URL.String()
+
+	apiKey, auth := req.Header.Get(apiKeyHeader), req.Header.Get("Authorization")
+	if apiKey != "" || auth != "" {
+		sum := sha256.Sum256([]byte(apiKey + "\n" + auth))
+		key += " " + hex.EncodeToString(sum[:])
+	}
+
+	return key
+}
+
+// cacheControl the directives of the Cache-Control header on a response.
+type cacheControl struct {
+	// ttl the amount of time the response is fresh for.
+	ttl time.Duration
+	// noStore the response must not be stored.
+	noStore bool
+	// private the response is specific to the user and must not be stored in a shared cache.
+	private bool
+	// mustRevalidate the response must not be served once it has expired without revalidation.
+	mustRevalidate bool
+}
+
+// parseCacheControl parses the Cache-Control header of a response. The max-age defined on
+// the response takes precedence over the default TTL. Responses with a max-age of zero, or
+// which are marked as no-cache, are stored but revalidated on every use, as are responses
+// marked as must-revalidate which do not define a max-age.
+func parseCacheControl(h http.Header, def time.Duration) cacheControl {
+	var (
+		cc     cacheControl
+		maxAge = int64(-1)
+		always bool
+	)
+
+	for _, directive := range strings.Split(h.Get(cacheControlHeader), ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store":
+			cc.noStore = true
+		case directive == "no-cache":
+			always = true
+		case directive == "private":
+			cc.private = true
+		case directive == "must-revalidate":
+			cc.mustRevalidate = true
+		case strings.HasPrefix(directive, "max-age="):
+			age, err := strconv.ParseInt(strings.TrimPrefix(directive, "max-age="), 10, 64)
+			if err == nil && age >= 0 {
+				maxAge = age
+			}
+		}
+	}
+
+	switch {
+	case always:
+		cc.ttl = 0
+	case maxAge >= 0:
+		cc.ttl = time.Duration(maxAge) * time.Second
+	case cc.mustRevalidate:
+		cc.ttl = 0
+	default:
+		cc.ttl = def
+	}
+
+	return cc
+}
+
+// storable determines if a response with the supplied directives can be stored in the cache.
+func (s *backendImplementation) storable(cc cacheControl) bool {
+	if cc.noStore {
+		return false
+	}
+
+	if !cc.private {
+		return true
+	}
+
+	p, ok := s.cache.(privateCache)
+	return ok && p.private()
+}
+
+// cacheLookup retrieves any cached entry for the request. If the entry has expired
+// the request is updated to revalidate the entry using its ETag.
+func (s *backendImplementation) cacheLookup(req *http.Request) (string, *CachedResponse) {
+	if s.cache == nil || req.Method != http.MethodGet {
+		return "", nil
+	}
+
+	key := cacheKey(req)
+	entry, ok := s.cache.Get(key)
+	if !ok || entry == nil {
+		return key, nil
+	}
+
+	if !entry.Fresh(time.Now()) && entry.ETag != "" {
+		req.Header.Set(ifNoneMatchHeader, entry.ETag)
+	}
+
+	return key, entry
+}
+
+// cacheStore updates the cache using the result of a request. This handles serving the cached
+// entry if it has not been modified, or if we failed to reach trakt. Otherwise, any successful
+// response is stored.
+func (s *backendImplementation) cacheStore(
+	key string, entry *CachedResponse, req *http.Request, res *http.Response, body []byte, err error,
+) (*http.Response, []byte, error) {
+	if key == "" {
+		return res, body, err
+	}
+
+	now := time.Now()
+
+	// if we failed to reach trakt, serve the stale entry if we have one.
+	if err != nil {
+		if entry == nil || entry.MustRevalidate || !isNetworkFailure(req.Context(), err) {
+			return res, body, err
+		}
+
+		s.logger.Warn("Serving stale response", append(requestFields(req), F(FieldError, err))...)
+		return entry.response(req), entry.Body, nil
+	}
+
+	// the entry is still valid, refresh its TTL.
+	if res.StatusCode == http.StatusNotModified && entry != nil {
+		refreshed := *entry
+		if cc := parseCacheControl(res.Header, s.cacheTTL); res.Header.Get(cacheControlHeader) != "" {
+			refreshed.ExpiresAt, refreshed.MustRevalidate = now.Add(cc.ttl), cc.mustRevalidate
+		} else {
+			refreshed.ExpiresAt = now.Add(entry.ExpiresAt.Sub(entry.StoredAt))
+		}
+
+		s.cache.Set(key, &refreshed)
+
+		s.logger.Info("Cached response revalidated", requestFields(req)...)
+		return refreshed.response(req), refreshed.Body, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return res, body, err
+	}
+
+	cc := parseCacheControl(res.Header, s.cacheTTL)
+	if !s.storable(cc) {
+		s.cache.Delete(key)
+		return res, body, err
+	}
+
+	s.cache.Set(key, &CachedResponse{
+		StatusCode:     res.StatusCode,
+		Header:         res.Header.Clone(),
+		Body:           body,
+		ETag:           res.Header.Get(etagHeader),
+		StoredAt:       now,
+		ExpiresAt:      now.Add(cc.ttl),
+		MustRevalidate: cc.mustRevalidate,
+	})
+
+	return res, body, err
+}
+
+// isNetworkFailure determines if an error means that we failed to reach trakt, this includes
+// failing to connect, trakt being unavailable or the circuit breaker being open.
+func isNetworkFailure(ctx context.Context, err error) bool {
+	// if the request was cancelled by the caller, it is not a network failure.
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if isNetworkError(err) || errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+
+	var traktErr *Error
+	return errors.As(err, &traktErr) && traktErr.HTTPStatusCode >= http.StatusInternalServerError
+}
+
+// memoryCache is an in-memory Cache which evicts the least recently used
+// entry once its capacity is reached.
+type memoryCache struct {
+	sync.Mutex
+
+	// size the maximum amount of entries the cache can hold.
+	size int
+	// ll the list of entries, ordered by most recently used.
+	ll *list.List
+	// entries the elements in the list, keyed by their cache key.
+	entries map[string]*list.Element
+}
+
+// memoryCacheEntry a single entry in the memory cache.
+type memoryCacheEntry struct {
+	key string
+	res *CachedResponse
+}
+
+// NewMemoryCache initialises a new in-memory LRU cache which holds at most size entries.
+// if size is less than or equal to zero, a default size is used.
+func NewMemoryCache(size int) Cache {
+	if size <= 0 {
+		size = defaultMemoryCacheSize
+	}
+
+	return &memoryCache{size: size, ll: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Get implements Cache interface.
+func (m *memoryCache) Get(key string) (*CachedResponse, bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	m.ll.MoveToFront(el)
+	return el.Value.(*memoryCacheEntry).res, true
+}
+
+// Set implements Cache interface.
+func (m *memoryCache) Set(key string, r *CachedResponse) {
+	m.Lock()
+	defer m.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		el.Value.(*memoryCacheEntry).res = r
+		m.ll.MoveToFront(el)
+		return
+	}
+
+	m.entries[key] = m.ll.PushFront(&memoryCacheEntry{key: key, res: r})
+	for m.ll.Len() > m.size {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// private implements privateCache interface.
+func (m *memoryCache) private() bool { return true }
+
+// Delete implements Cache interface.
+func (m *memoryCache) Delete(key string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.ll.Remove(el)
+		delete(m.entries, key)
+	}
+}
@@ -0,0 +1,149 @@
+package trakt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseCacheControl(t *testing.T) {
+	const def = 5 * time.Minute
+
+	tests := []struct {
+		header string
+		want   cacheControl
+	}{
+		{header: "", want: cacheControl{ttl: def}},
+		{header: "max-age=60", want: cacheControl{ttl: time.Minute}},
+		{header: "max-age=0", want: cacheControl{}},
+		{header: "no-cache", want: cacheControl{}},
+		{header: "no-cache, max-age=60", want: cacheControl{}},
+		{header: "no-store", want: cacheControl{ttl: def, noStore: true}},
+		{header: "private, max-age=60", want: cacheControl{ttl: time.Minute, private: true}},
+		{header: "must-revalidate", want: cacheControl{mustRevalidate: true}},
+		{header: "Must-Revalidate, Max-Age=60", want: cacheControl{ttl: time.Minute, mustRevalidate: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			h := http.Header{}
+			h.Set(cacheControlHeader, tt.header)
+			if got := parseCacheControl(h, def); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	req := func(key, auth string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "https://api.trakt.tv/sync/history", nil)
+		r.Header.Set(apiKeyHeader, key)
+		if auth != "" {
+			r.Header.Set("Authorization", auth)
+		}
+
+		return r
+	}
+
+	if cacheKey(req("a", "")) == cacheKey(req("b", "")) {
+		t.Error("expected requests with different client ids to use different keys")
+	}
+
+	if cacheKey(req("a", "Bearer x")) == cacheKey(req("a", "Bearer y")) {
+		t.Error("expected requests with different tokens to use different keys")
+	}
+
+	if cacheKey(req("a", "Bearer x")) != cacheKey(req("a", "Bearer x")) {
+		t.Error("expected identical requests to use the same key")
+	}
+}
+
+// cacheServer returns a server which responds with the supplied Cache-Control header and
+// an ETag, counting the requests which were revalidated.
+func cacheServer(cc string, hits, revalidated *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.Header().Set(cacheControlHeader, cc)
+		w.Header().Set(etagHeader, `"v1"`)
+		if r.Header.Get(ifNoneMatchHeader) == `"v1"` {
+			atomic.AddInt32(revalidated, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+}
+
+func TestBackend_CacheRevalidate(t *testing.T) {
+	tests := []struct {
+		header      string
+		hits        int32
+		revalidated int32
+	}{
+		{header: "max-age=60", hits: 1},
+		{header: "max-age=0", hits: 3, revalidated: 2},
+		{header: "no-cache", hits: 3, revalidated: 2},
+		{header: "must-revalidate", hits: 3, revalidated: 2},
+		{header: "no-store", hits: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			var hits, revalidated int32
+			srv := cacheServer(tt.header, &hits, &revalidated)
+			defer srv.Close()
+
+			b := NewBackend(&BackendConfig{URL: srv.URL, GetRateLimit: NoRateLimit, Cache: NewMemoryCache(0)})
+			for i := 0; i < 3; i++ {
+				if err := b.Call(http.MethodGet, "/movies/trending", "key", &BasicParams{}, &struct{}{}); err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+			}
+
+			if hits != tt.hits || revalidated != tt.revalidated {
+				t.Errorf("expected %d requests with %d revalidated, got %d with %d",
+					tt.hits, tt.revalidated, hits, revalidated)
+			}
+		})
+	}
+}
+
+func TestBackend_CachePrivate(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		cache  Cache
+		stored bool
+	}{
+		{name: "memory", cache: NewMemoryCache(0), stored: true},
+		{name: "disk", cache: disk, stored: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits, revalidated int32
+			srv := cacheServer("private, max-age=60", &hits, &revalidated)
+			defer srv.Close()
+
+			b := NewBackend(&BackendConfig{URL: srv.URL, GetRateLimit: NoRateLimit, Cache: tt.cache})
+			if err := b.Call(http.MethodGet, "/sync/history", "key", &Params{OAuth: "token"}, &struct{}{}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, srv.URL+"/sync/history?", nil)
+			req.Header.Set(apiKeyHeader, "key")
+			req.Header.Set("Authorization", "Bearer token")
+			if _, ok := tt.cache.Get(cacheKey(req)); ok != tt.stored {
+				t.Errorf("expected stored to be %v, got %v", tt.stored, ok)
+			}
+		})
+	}
+}
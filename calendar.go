@@ -91,13 +91,19 @@ func (li *CalendarShowIterator) Entry() (*CalendarShow, error) {
 	return rcv, li.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (li *CalendarShowIterator) Iter() *Iter[*CalendarShow] { return newIter(li, li.Entry) }
+
 // CalendarMovieIterator represents a list of calendar movies which can be iterated.
 type CalendarMovieIterator struct{ Iterator }
 
 // Entry attempts to return an CalendarMovie entry at the current cursor in
 // the iterator. Returns an error if there no cursor (Next hasnt been called yet)
 // or if there is an error on the iterator retrieving a page of results.
-func (li *CommentIterator) Entry() (*CalendarMovie, error) {
+func (li *CalendarMovieIterator) Entry() (*CalendarMovie, error) {
 	rcv := &CalendarMovie{}
 	return rcv, li.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (li *CalendarMovieIterator) Iter() *Iter[*CalendarMovie] { return newIter(li, li.Entry) }
@@ -23,8 +23,8 @@ const (
 	timeFormat = "2006-01-02"
 )
 
-// client the calendar client used to make requests.
-type client struct{ b trakt.BaseClient }
+// Client the calendar client used to make requests.
+type Client struct{ b trakt.PathClient }
 
 // MyShows returns all shows airing during the time period specified for the authenticated user.
 //
@@ -38,7 +38,7 @@ func MyShows(p *trakt.CalendarParams) *trakt.CalendarShowIterator { return getC(
 //  - OAuth Required
 //  - Extended Info
 //  - Filters
-func (c *client) MyShows(params *trakt.CalendarParams) *trakt.CalendarShowIterator {
+func (c *Client) MyShows(params *trakt.CalendarParams) *trakt.CalendarShowIterator {
 	return c.shows(scopeAuthenticated, &wrappedCalendarParams{params})
 }
 
@@ -56,7 +56,7 @@ func MyNewShows(p *trakt.CalendarParams) *trakt.CalendarShowIterator { return ge
 //  - OAuth Required
 //  - Extended Info
 //  - Filters
-func (c *client) MyNewShows(params *trakt.CalendarParams) *trakt.CalendarShowIterator {
+func (c *Client) MyNewShows(params *trakt.CalendarParams) *trakt.CalendarShowIterator {
 	return c.newShows(scopeAuthenticated, &wrappedCalendarParams{params})
 }
 
@@ -76,7 +76,7 @@ func MySeasonPremieres(p *trakt.CalendarParams) *trakt.CalendarShowIterator {
 //  - OAuth Required
 //  - Extended Info
 //  - Filters
-func (c *client) MySeasonPremieres(params *trakt.CalendarParams) *trakt.CalendarShowIterator {
+func (c *Client) MySeasonPremieres(params *trakt.CalendarParams) *trakt.CalendarShowIterator {
 	return c.seasonPremieres(scopeAuthenticated, &wrappedCalendarParams{params})
 }
 
@@ -90,7 +90,7 @@ func Shows(p *trakt.BasicCalendarParams) *trakt.CalendarShowIterator { return ge
 //
 //  - Extended Info
 //  - Filters
-func (c *client) Shows(params *trakt.BasicCalendarParams) *trakt.CalendarShowIterator {
+func (c *Client) Shows(params *trakt.BasicCalendarParams) *trakt.CalendarShowIterator {
 	return c.shows(scopeAll, &wrappedBasicCalendarParams{params})
 }
 
@@ -106,7 +106,7 @@ func NewShows(params *trakt.BasicCalendarParams) *trakt.CalendarShowIterator {
 //
 //  - Extended Info
 //  - Filters
-func (c *client) NewShows(params *trakt.BasicCalendarParams) *trakt.CalendarShowIterator {
+func (c *Client) NewShows(params *trakt.BasicCalendarParams) *trakt.CalendarShowIterator {
 	return c.newShows(scopeAll, &wrappedBasicCalendarParams{params})
 }
 
@@ -124,42 +124,42 @@ func SeasonPremieres(params *trakt.BasicCalendarParams) *trakt.CalendarShowItera
 //
 //  - Extended Info
 //  - Filters
-func (c *client) SeasonPremieres(params *trakt.BasicCalendarParams) *trakt.CalendarShowIterator {
+func (c *Client) SeasonPremieres(params *trakt.BasicCalendarParams) *trakt.CalendarShowIterator {
 	return c.seasonPremieres(scopeAll, &wrappedBasicCalendarParams{params})
 }
 
 // shows helper function which generates an iterator for a list of shows under the supplied scope.
-func (c *client) shows(scope scope, params calendarParams) *trakt.CalendarShowIterator {
+func (c *Client) shows(scope scope, params calendarParams) *trakt.CalendarShowIterator {
 	return c.generateShowIterator(trakt.FormatURLPath("/calendars/%s/shows", scope), params)
 }
 
 // newShows helper function which generates an iterator for a list of new shows under the supplied scope.
-func (c *client) newShows(scope scope, params calendarParams) *trakt.CalendarShowIterator {
+func (c *Client) newShows(scope scope, params calendarParams) *trakt.CalendarShowIterator {
 	return c.generateShowIterator(trakt.FormatURLPath("/calendars/%s/shows/new", scope), params)
 }
 
 // seasonPremieres helper function which generates an iterator for a list of season premieres
 // under the supplied scope.
-func (c *client) seasonPremieres(scope scope, params calendarParams) *trakt.CalendarShowIterator {
+func (c *Client) seasonPremieres(scope scope, params calendarParams) *trakt.CalendarShowIterator {
 	return c.generateShowIterator(trakt.FormatURLPath("/calendars/%s/shows/premieres", scope), params)
 }
 
 // generateShowIterator generates an iterator to retrieve calender shows for the supplied
 // path and params.
-func (c *client) generateShowIterator(path string, params calendarParams) *trakt.CalendarShowIterator {
-	return &trakt.CalendarShowIterator{Iterator: c.b.NewIterator(http.MethodGet, formatPath(path, params), params.elem())}
+func (c *Client) generateShowIterator(path string, params calendarParams) *trakt.CalendarShowIterator {
+	return &trakt.CalendarShowIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, formatPath(path, params), params.elem())}
 }
 
 // formatPath formats the arguments from the supplied parameters into the path, setting
 // default where required.
-func formatPath(path string, c calendarParams) string {
+func formatPath(path string, c calendarParams) trakt.Path {
 	var days = c.days()
 	if days == 0 {
 		days = 7
 	}
 
 	date := c.startDate().Format(timeFormat)
-	return trakt.FormatURLPath(path+"/%s/%s", date, strconv.Itoa(days))
+	return trakt.NewPath(path+"/%s/%s", date, strconv.Itoa(days))
 }
 
 // calendarParams a generic interface for a set of params designed to
@@ -209,4 +209,9 @@ func (w *wrappedBasicCalendarParams) days() int { return int(w.Days) }
 // elem implements the calendarParams interface.
 func (w *wrappedBasicCalendarParams) elem() trakt.ListParamsContainer { return w.BasicCalendarParams }
 
-func getC() *client { return &client{trakt.NewClient()} }
+// NewClient initialises a new calendar client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
+// getC initialises a new calendar client with the currently defined backend.
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
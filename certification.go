@@ -20,3 +20,6 @@ func (c *CertificationIterator) Certification() (*Certification, error) {
 	rcv := &Certification{}
 	return rcv, c.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (c *CertificationIterator) Iter() *Iter[*Certification] { return newIter(c, c.Certification) }
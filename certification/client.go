@@ -12,8 +12,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client the certification client.
-type client struct{ b trakt.BaseClient }
+// Client the certification client.
+type Client struct{ b trakt.PathClient }
 
 // List returns a list of all certifications, including names, slugs, and descriptions for a particular
 // media type. Only TypeMovie and TypeShow are supported.
@@ -23,10 +23,10 @@ func List(params *trakt.ListByTypeParams) *trakt.CertificationIterator {
 
 // List returns a list of all certifications, including names, slugs, and descriptions for a particular
 // media type. Only TypeMovie and TypeShow are supported.
-func (c *client) List(params *trakt.ListByTypeParams) *trakt.CertificationIterator {
-	path := trakt.FormatURLPath("/certifications/%s", params.Type.Plural())
+func (c *Client) List(params *trakt.ListByTypeParams) *trakt.CertificationIterator {
+	path := trakt.NewPath("/certifications/%s", params.Type.Plural())
 	return &trakt.CertificationIterator{
-		BasicIterator: c.b.NewSimulatedIteratorWithCondition(http.MethodGet, path, params, func() error {
+		BasicIterator: c.b.NewSimulatedIteratorWithConditionPath(http.MethodGet, path, params, func() error {
 			switch params.Type {
 			case trakt.TypeMovie, trakt.TypeShow:
 				return nil
@@ -35,12 +35,16 @@ func (c *client) List(params *trakt.ListByTypeParams) *trakt.CertificationIterat
 			return &trakt.Error{
 				HTTPStatusCode: http.StatusUnprocessableEntity,
 				Body:           "invalid type: only movie / show are applicable",
-				Resource:       path,
+				Resource:       path.Path,
 				Code:           trakt.ErrorCodeValidationError,
 			}
 		}),
 	}
 }
 
+// NewClient initialises a new certification client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC retrieves an instance of a certification client.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
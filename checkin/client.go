@@ -13,8 +13,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a client which can be used to perform checkin requests.
-type client struct{ b trakt.BaseClient }
+// Client represents a client which can be used to perform checkin requests.
+type Client struct{ b trakt.PathClient }
 
 // Start Check into a movie or episode. This should be tied to a user action to manually indicate
 // they are watching something. The item will display as watching on the site, then
@@ -46,7 +46,7 @@ func Start(params *trakt.StartCheckinParams) (*trakt.Checkin, error) {
 // as these always have a mapping.
 //
 //  - OAuth Required
-func (c *client) Start(params *trakt.StartCheckinParams) (*trakt.Checkin, error) {
+func (c *Client) Start(params *trakt.StartCheckinParams) (*trakt.Checkin, error) {
 	switch params.Type {
 	case trakt.TypeMovie, trakt.TypeEpisode:
 		break
@@ -61,7 +61,7 @@ func (c *client) Start(params *trakt.StartCheckinParams) (*trakt.Checkin, error)
 
 	ci := &trakt.Checkin{}
 	p := &wrappedCheckinParams{params}
-	err := c.b.Call(http.MethodPost, "/checkin", p, ci)
+	err := c.b.CallPath(http.MethodPost, trakt.NewPath("/checkin"), p, ci)
 	return ci, err
 }
 
@@ -75,8 +75,8 @@ func Stop(params *trakt.Params) error {
 // Stop removes any active check-ins, no need to provide a specific item.
 //
 //  - OAuth Required
-func (c *client) Stop(params *trakt.Params) error {
-	return c.b.Call(http.MethodDelete, "/checkin", params, nil)
+func (c *Client) Stop(params *trakt.Params) error {
+	return c.b.CallPath(http.MethodDelete, trakt.NewPath("/checkin"), params, nil)
 }
 
 // wrappedCheckinParams provides a wrapper around checkin parameters
@@ -93,5 +93,9 @@ func (wrappedCheckinParams) Code(statusCode int) trakt.ErrorCode {
 	return trakt.DefaultErrorHandler.Code(statusCode)
 }
 
+// NewClient initialises a new checkin client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new checkin client with the currently defined backend.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -0,0 +1,114 @@
+package trakt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// coalescedCall a round-trip which is in-flight and shared by every identical request.
+type coalescedCall struct {
+	// done is closed once the round-trip has completed.
+	done chan struct{}
+
+	res  *http.Response
+	body []byte
+	err  error
+}
+
+// coalescer deduplicates concurrent identical requests so that they share a single round-trip.
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+// newCoalescer initialises a new coalescer.
+func newCoalescer() *coalescer { return &coalescer{calls: make(map[string]*coalescedCall)} }
+
+// do performs fn, unless an identical request is already in-flight in which case we wait for
+// its result instead. shared is true if the result came from another request. An error is
+// only returned if the context is done while waiting for another request.
+func (c *coalescer) do(
+	ctx context.Context, key string, fn func() (*http.Response, []byte, error),
+) (call *coalescedCall, shared bool, err error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+
+		select {
+		case <-call.done:
+			return call, true, nil
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+
+	call = &coalescedCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.calls, key)
+		c.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.res, call.body, call.err = fn()
+	return call, false, nil
+}
+
+// coalescedRoundTrip performs a GET request, sharing the round-trip with any identical request
+// which is already in-flight. Requests are identical if they share the same method, URL, client id
+// and OAuth token. Each caller receives its own copy of the response so it can be decoded independently.
+func (s *backendImplementation) coalescedRoundTrip(
+	info *RequestInfo, out *ResponseInfo, req *http.Request, params ParamsContainer,
+) (*http.Response, []byte, error) {
+	ctx := params.context()
+	call, shared, err := s.coalescer.do(ctx, cacheKey(req), func() (*http.Response, []byte, error) {
+		return s.roundTrip(info, out, req, nil, params)
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, body, err := call.res, call.body, call.err
+	if !shared {
+		return res, body, err
+	}
+
+	// the request we shared was cancelled by its caller, which does not mean this request
+	// has to fail, so perform it ourselves.
+	if isContextError(err) && ctx.Err() == nil {
+		return s.roundTrip(info, out, req, nil, params)
+	}
+
+	s.logger.Info("Sharing in-flight response", requestFields(req)...)
+	out.Coalesced = true
+	if res != nil {
+		info.received(res)
+		res = copyResponse(res, req)
+	}
+
+	var traktErr *Error
+	if errors.As(err, &traktErr) {
+		cp := *traktErr
+		err = &cp
+	}
+
+	return res, body, err
+}
+
+// copyResponse generates a copy of the shared response for the supplied request.
+func copyResponse(res *http.Response, req *http.Request) *http.Response {
+	cp := *res
+	cp.Header, cp.Request = res.Header.Clone(), req
+	return &cp
+}
+
+// isContextError determines if the error is because a context was cancelled or exceeded its deadline.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
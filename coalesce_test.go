@@ -0,0 +1,80 @@
+package trakt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackend_CoalesceRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		params func(i int) (string, ParamsContainer)
+		hits   int32
+	}{
+		{
+			name:   "identical",
+			params: func(int) (string, ParamsContainer) { return "key", &BasicParams{} },
+			hits:   1,
+		},
+		{
+			name: "different client ids",
+			params: func(i int) (string, ParamsContainer) {
+				return []string{"a", "b"}[i], &BasicParams{}
+			},
+			hits: 2,
+		},
+		{
+			name: "different tokens",
+			params: func(i int) (string, ParamsContainer) {
+				return "key", &Params{OAuth: []string{"a", "b"}[i]}
+			},
+			hits: 2,
+		},
+		{
+			name: "custom headers",
+			params: func(int) (string, ParamsContainer) {
+				return "key", &BasicParams{Headers: http.Header{"X-Custom": []string{"value"}}}
+			},
+			hits: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int32
+			release := make(chan struct{})
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				atomic.AddInt32(&hits, 1)
+				<-release
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			b := NewBackend(&BackendConfig{URL: srv.URL, GetRateLimit: NoRateLimit, CoalesceRequests: true})
+
+			var wg sync.WaitGroup
+			for i := 0; i < 2; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					key, params := tt.params(i)
+					if err := b.Call(http.MethodGet, "/movies/popular", key, params, &struct{}{}); err != nil {
+						t.Errorf("expected no error, got %v", err)
+					}
+				}(i)
+			}
+
+			time.Sleep(50 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			if hits != tt.hits {
+				t.Errorf("expected %d requests, got %d", tt.hits, hits)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package trakt
+
+import (
+	"context"
+	"sync"
+)
+
+// mediaKey the key used to deduplicate entries, the type is only defined for
+// entries which can contain different types of media, i.e search results.
+type mediaKey struct {
+	typ Type
+	id  ID
+}
+
+// traktIdentifier is implemented by entries which are identified by the trakt ID in their MediaIDs,
+// such as movies, shows, episodes and people, and entries embedding them, i.e trending movies.
+type traktIdentifier interface {
+	mediaKey() mediaKey
+}
+
+func (m MediaIDs) mediaKey() mediaKey { return mediaKey{id: m.Trakt} }
+
+func (s *SearchResult) mediaKey() mediaKey {
+	var ids MediaIDs
+	switch {
+	case s.Movie != nil:
+		ids = s.Movie.MediaIDs
+	case s.Episode != nil:
+		ids = s.Episode.MediaIDs
+	case s.Show != nil:
+		ids = s.Show.MediaIDs
+	case s.Person != nil:
+		ids = s.Person.MediaIDs
+	case s.List != nil:
+		ids.Trakt = s.List.Trakt
+	}
+
+	return mediaKey{typ: s.Type, id: ids.Trakt}
+}
+
+// Collect returns every remaining entry:
+//
+//  shows, err := show.Popular(params).Iter().Take(50).Collect()
+func (i *Iter[T]) Collect() ([]T, error) {
+	var entries []T
+	err := i.ForEach(context.Background(), func(v T) error {
+		entries = append(entries, v)
+		return nil
+	})
+
+	return entries, err
+}
+
+// Take returns an iterator over the first n remaining entries. Pages after the page containing
+// the last entry are not retrieved by Take, although they may have already been retrieved if
+// Prefetch is enabled on the wrapped iterator.
+func (i *Iter[T]) Take(n int) *Iter[T] {
+	var taken int
+	return i.derive(func() (T, bool, error) {
+		var zero T
+		if taken >= n || !i.Next() {
+			return zero, false, nil
+		}
+
+		taken++
+		v, err := i.Current()
+		return v, err == nil, err
+	})
+}
+
+// Filter returns an iterator over the remaining entries which fn returns true for:
+//
+//  it := movie.Popular(params).Iter().Filter(func(m *trakt.Movie) bool { return m.Year >= 2000 })
+func (i *Iter[T]) Filter(fn func(T) bool) *Iter[T] {
+	return i.derive(func() (T, bool, error) {
+		for i.Next() {
+			v, err := i.Current()
+			if err != nil {
+				return v, false, err
+			}
+
+			if fn(v) {
+				return v, true, nil
+			}
+		}
+
+		var zero T
+		return zero, false, nil
+	})
+}
+
+// Dedup returns an iterator which skips entries which have already been seen, based on their trakt ID.
+// This is useful for lists which can shift between pages being retrieved, such as trending lists. Entries
+// without a trakt ID are never skipped.
+func Dedup[T traktIdentifier](it *Iter[T]) *Iter[T] {
+	return DedupFunc(it, func(v T) mediaKey {
+		if k := v.mediaKey(); k.id != 0 {
+			return k
+		}
+
+		// entries without an ID are unique.
+		return mediaKey{}
+	})
+}
+
+// DedupFunc returns an iterator which skips entries with a key which has already been seen.
+// The zero value of K is never considered a duplicate.
+func DedupFunc[T any, K comparable](it *Iter[T], key func(T) K) *Iter[T] {
+	var zero K
+	seen := make(map[K]struct{})
+	return it.Filter(func(v T) bool {
+		k := key(v)
+		if k == zero {
+			return true
+		}
+
+		if _, ok := seen[k]; ok {
+			return false
+		}
+
+		seen[k] = struct{}{}
+		return true
+	})
+}
+
+// derive returns an iterator which uses next to move to the next entry, next returns the entry
+// and whether there is one. The wrapped iterator is used for the cursor and pagination.
+func (i *Iter[T]) derive(next func() (T, bool, error)) *Iter[T] {
+	var (
+		mu  sync.Mutex
+		cur T
+		err error
+	)
+
+	return &Iter[T]{
+		it: i.it,
+		next: func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			var ok bool
+			if err != nil {
+				return false
+			}
+
+			cur, ok, err = next()
+			return ok
+		},
+		scan: func() (T, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return cur, nil
+		},
+		err: func() error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				return err
+			}
+
+			return i.Err()
+		},
+	}
+}
@@ -0,0 +1,167 @@
+package trakt
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIter_Take(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		entries []int
+		pages   []int64
+	}{
+		{name: "none", n: 0, pages: nil},
+		{name: "first page", n: 3, entries: []int{1, 2, 3}, pages: []int64{1}},
+		{name: "second page", n: 4, entries: []int{1, 2, 3, 4}, pages: []int64{1, 2}},
+		{name: "more than available", n: 20, entries: []int{1, 2, 3, 4, 5, 6, 7}, pages: []int64{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &pagedQuery{n: 7, size: 3}
+			entries, err := NewIter[int](q.iterator(true)).Take(tt.n).Collect()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if !reflect.DeepEqual(entries, tt.entries) {
+				t.Errorf("expected %v, got %v", tt.entries, entries)
+			}
+
+			if pages := q.requested(); !reflect.DeepEqual(pages, tt.pages) {
+				t.Errorf("expected pages %v to be requested, got %v", tt.pages, pages)
+			}
+		})
+	}
+}
+
+func TestIter_Filter(t *testing.T) {
+	q := &pagedQuery{n: 10, size: 3}
+	entries, err := NewIter[int](q.iterator(false)).Filter(func(v int) bool { return v%2 == 0 }).Collect()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if want := []int{2, 4, 6, 8, 10}; !reflect.DeepEqual(entries, want) {
+		t.Errorf("expected %v, got %v", want, entries)
+	}
+}
+
+func TestIter_Collect(t *testing.T) {
+	tests := []struct {
+		name    string
+		fail    int64
+		entries []int
+		err     bool
+	}{
+		{name: "every entry", entries: []int{1, 2, 3, 4, 5}},
+		{name: "error", fail: 2, entries: []int{1, 2}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &pagedQuery{n: 5, size: 2, fail: tt.fail}
+			entries, err := NewIter[int](q.iterator(false)).Collect()
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error to be %v, got %v", tt.err, err)
+			}
+
+			if !reflect.DeepEqual(entries, tt.entries) {
+				t.Errorf("expected %v, got %v", tt.entries, entries)
+			}
+		})
+	}
+}
+
+// searchResult generates a search result of the supplied type with the trakt ID.
+func searchResult(typ Type, id ID) *SearchResult {
+	r := &SearchResult{}
+	r.Type = typ
+
+	switch typ {
+	case TypeMovie:
+		r.Movie = &Movie{}
+		r.Movie.Trakt = id
+	case TypeShow:
+		r.Show = &Show{}
+		r.Show.Trakt = id
+	case TypeEpisode:
+		r.Episode = &Episode{}
+		r.Episode.Trakt = id
+	case TypePerson:
+		r.Person = &Person{}
+		r.Person.Trakt = id
+	case TypeList:
+		r.List = &List{}
+		r.List.Trakt = id
+	}
+
+	return r
+}
+
+func TestSearchResult_MediaKey(t *testing.T) {
+	for _, typ := range []Type{TypeMovie, TypeShow, TypeEpisode, TypePerson, TypeList} {
+		t.Run(string(typ), func(t *testing.T) {
+			want := mediaKey{typ: typ, id: 10}
+			if k := searchResult(typ, 10).mediaKey(); k != want {
+				t.Errorf("expected %v, got %v", want, k)
+			}
+		})
+	}
+}
+
+func TestDedup(t *testing.T) {
+	results := []*SearchResult{
+		searchResult(TypeMovie, 1),
+		searchResult(TypeShow, 1),
+		searchResult(TypeMovie, 1),
+		searchResult(TypePerson, 2),
+		searchResult(TypeMovie, 0),
+		searchResult(TypeMovie, 0),
+		searchResult(TypeList, 2),
+		searchResult(TypePerson, 2),
+	}
+
+	// the entries served are the index of the result, offset by one.
+	q := &pagedQuery{n: int64(len(results)), size: 3}
+	it := q.iterator(false)
+	typed := NewIterFunc(it, func() (*SearchResult, error) {
+		var i int
+		err := it.Scan(&i)
+		return results[i-1], err
+	})
+
+	entries, err := Dedup(typed).Collect()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []*SearchResult{results[0], results[1], results[3], results[4], results[5], results[6]}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("expected %d unique results, got %d", len(want), len(entries))
+	}
+}
+
+func TestDedupFunc(t *testing.T) {
+	q := &pagedQuery{n: 9, size: 2}
+	entries, err := DedupFunc(NewIter[int](q.iterator(false)), func(v int) int { return v % 4 }).Collect()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// entries with the zero key, 4 and 8, are never duplicates.
+	if want := []int{1, 2, 3, 4, 8}; !reflect.DeepEqual(entries, want) {
+		t.Errorf("expected %v, got %v", want, entries)
+	}
+}
+
+func TestDedup_Error(t *testing.T) {
+	q := &pagedQuery{n: 5, size: 2, fail: 3}
+	_, err := DedupFunc(NewIter[int](q.iterator(false)), func(v int) int { return v }).Collect()
+	if !errors.Is(err, ErrorCodeServerError) {
+		t.Errorf("expected %v, got %v", ErrorCodeServerError, err)
+	}
+}
@@ -141,6 +141,21 @@ func (li *CommentIterator) Comment() (*Comment, error) {
 	return rcv, li.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (li *CommentIterator) Iter() *Iter[*Comment] { return newIter(li, li.Comment) }
+
+// Entry attempts to return an CalendarMovie entry at the current cursor in
+// the iterator.
+//
+// Deprecated: Entry was declared on CommentIterator in error rather than on
+// CalendarMovieIterator, it is kept so existing callers continue to compile.
+// Use Comment to retrieve a comment, or CalendarMovieIterator.Entry to retrieve
+// a calendar movie.
+func (li *CommentIterator) Entry() (*CalendarMovie, error) {
+	rcv := &CalendarMovie{}
+	return rcv, li.Scan(rcv)
+}
+
 // UserLike represents a user which has liked a comment
 type UserLike struct {
 	// User the user who liked the reply
@@ -160,6 +175,9 @@ func (li *UserLikeIterator) UserLike() (*UserLike, error) {
 	return rcv, li.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (li *UserLikeIterator) Iter() *Iter[*UserLike] { return newIter(li, li.UserLike) }
+
 // CommentWithMediaElement represents a comment with the media element its attached to
 type CommentWithMediaElement struct {
 	// GenericMediaElement the media element the comment is attached to.
@@ -179,3 +197,8 @@ func (li *CommentWithMediaElementIterator) CommentWithMediaElement() (*CommentWi
 	rcv := &CommentWithMediaElement{}
 	return rcv, li.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (li *CommentWithMediaElementIterator) Iter() *Iter[*CommentWithMediaElement] {
+	return newIter(li, li.CommentWithMediaElement)
+}
@@ -6,18 +6,18 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a client which is capable of perform comment
+// Client represents a client which is capable of perform comment
 // based operations, utilising the base client.
-type client struct{ b trakt.BaseClient }
+type Client struct{ b trakt.PathClient }
 
 // Get returns a single comment and indicates how many replies it has. Use "Replies" to get the actual replies.
 func Get(id int64, params *trakt.BasicParams) (*trakt.Comment, error) { return getC().Get(id, params) }
 
 // Get returns a single comment and indicates how many replies it has. Use "Replies" to get the actual replies.
-func (c *client) Get(id int64, params *trakt.BasicParams) (*trakt.Comment, error) {
-	path := trakt.FormatURLPath("/comments/%s", id)
+func (c *Client) Get(id int64, params *trakt.BasicParams) (*trakt.Comment, error) {
+	path := trakt.NewPath("/comments/%s", id)
 	com := &trakt.Comment{}
-	err := c.b.Call(http.MethodGet, path, params, com)
+	err := c.b.CallPath(http.MethodGet, path, params, com)
 	return com, err
 }
 
@@ -31,9 +31,9 @@ func Likes(id int64, p *trakt.BasicListParams) *trakt.UserLikeIterator { return
 // object already has that, so no need to use this method.
 //
 //  - Pagination
-func (c *client) Likes(id int64, params *trakt.BasicListParams) *trakt.UserLikeIterator {
-	path := trakt.FormatURLPath("/comments/%s/likes", id)
-	return &trakt.UserLikeIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Likes(id int64, params *trakt.BasicListParams) *trakt.UserLikeIterator {
+	path := trakt.NewPath("/comments/%s/likes", id)
+	return &trakt.UserLikeIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Replies returns all replies for a comment. It is possible these replies could have replies themselves,
@@ -46,9 +46,9 @@ func Replies(id int64, p *trakt.ListParams) *trakt.CommentIterator { return getC
 // so in that case you would just call this function again with the new comment id.
 //
 //  - Pagination
-func (c *client) Replies(id int64, params *trakt.ListParams) *trakt.CommentIterator {
-	path := trakt.FormatURLPath("/comments/%s/replies", id)
-	return &trakt.CommentIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Replies(id int64, params *trakt.ListParams) *trakt.CommentIterator {
+	path := trakt.NewPath("/comments/%s/replies", id)
+	return &trakt.CommentIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Item returns the media item this comment is attached to. The media type can be movie,
@@ -63,10 +63,10 @@ func Item(id int64, p *trakt.ExtendedParams) (*trakt.GenericElement, error) {
 // show, season, episode, or list and it also returns the standard media object for that media type.
 //
 //  - Extended Info
-func (c *client) Item(id int64, params *trakt.ExtendedParams) (*trakt.GenericElement, error) {
-	path := trakt.FormatURLPath("/comments/%s/item", id)
+func (c *Client) Item(id int64, params *trakt.ExtendedParams) (*trakt.GenericElement, error) {
+	path := trakt.NewPath("/comments/%s/item", id)
 	com := &trakt.GenericElement{}
-	err := c.b.Call(http.MethodGet, path, params, com)
+	err := c.b.CallPath(http.MethodGet, path, params, com)
 	return com, err
 }
 
@@ -84,7 +84,7 @@ func Trending(p *trakt.TrendingCommentParams) *trakt.CommentWithMediaElementIter
 //
 //  - Pagination
 //  - Extended Info
-func (c *client) Trending(params *trakt.TrendingCommentParams) *trakt.CommentWithMediaElementIterator {
+func (c *Client) Trending(params *trakt.TrendingCommentParams) *trakt.CommentWithMediaElementIterator {
 	return c.generateIterator(`trending`, params)
 }
 
@@ -102,7 +102,7 @@ func Recent(params *trakt.RecentCommentParams) *trakt.CommentWithMediaElementIte
 //
 //  - Pagination
 //  - Extended Info
-func (c *client) Recent(params *trakt.RecentCommentParams) *trakt.CommentWithMediaElementIterator {
+func (c *Client) Recent(params *trakt.RecentCommentParams) *trakt.CommentWithMediaElementIterator {
 	return c.generateIterator(`recent`, params)
 }
 
@@ -120,7 +120,7 @@ func Updates(params *trakt.UpdatedCommentParams) *trakt.CommentWithMediaElementI
 //
 //  - Pagination
 //  - Extended Info
-func (c *client) Updates(params *trakt.UpdatedCommentParams) *trakt.CommentWithMediaElementIterator {
+func (c *Client) Updates(params *trakt.UpdatedCommentParams) *trakt.CommentWithMediaElementIterator {
 	return c.generateIterator(`updates`, params)
 }
 
@@ -164,9 +164,9 @@ func Post(p *trakt.PostCommentParams) (*trakt.Comment, error) { return getC().Po
 // "ErrorCodeValidationError"        - comment does not conform to rules set out above.
 //
 //  - OAuth Required
-func (c *client) Post(params *trakt.PostCommentParams) (*trakt.Comment, error) {
+func (c *Client) Post(params *trakt.PostCommentParams) (*trakt.Comment, error) {
 	com := &trakt.Comment{}
-	err := c.b.Call(http.MethodPost, "/comments", &wrappedPostCommentParams{PostCommentParams: params}, &com)
+	err := c.b.CallPath(http.MethodPost, trakt.NewPath("/comments"), &wrappedPostCommentParams{PostCommentParams: params}, &com)
 	return com, err
 }
 
@@ -184,11 +184,11 @@ func Update(id int64, p *trakt.UpdateCommentParams) (*trakt.Comment, error) {
 // is returned.
 //
 //  - OAuth Required
-func (c *client) Update(id int64, params *trakt.UpdateCommentParams) (*trakt.Comment, error) {
+func (c *Client) Update(id int64, params *trakt.UpdateCommentParams) (*trakt.Comment, error) {
 	com := &trakt.Comment{}
-	err := c.b.Call(
+	err := c.b.CallPath(
 		http.MethodPut,
-		trakt.FormatURLPath("/comments/%s", id),
+		trakt.NewPath("/comments/%s", id),
 		&wrappedUpdateCommentParams{UpdateCommentParams: params},
 		&com,
 	)
@@ -209,9 +209,9 @@ func Remove(id int64, p *trakt.Params) error { return getC().Remove(id, p) }
 // "ErrorCodeCommentCannotBeRemoved" error code is returned.
 //
 //  - OAuth Required
-func (c *client) Remove(id int64, params *trakt.Params) error {
-	return c.b.Call(
-		http.MethodDelete, trakt.FormatURLPath("/comment/%s", id),
+func (c *Client) Remove(id int64, params *trakt.Params) error {
+	return c.b.CallPath(
+		http.MethodDelete, trakt.NewPath("/comment/%s", id),
 		&wrappedRemoveCommentParams{Params: params}, nil,
 	)
 }
@@ -228,11 +228,11 @@ func AddReply(id int64, p *trakt.AddReplyParams) (*trakt.Comment, error) {
 // spoilers to be indicated in your app and follow the rules listed above.
 //
 //  - OAuth Required
-func (c *client) AddReply(id int64, params *trakt.AddReplyParams) (*trakt.Comment, error) {
+func (c *Client) AddReply(id int64, params *trakt.AddReplyParams) (*trakt.Comment, error) {
 	com := &trakt.Comment{}
-	err := c.b.Call(
+	err := c.b.CallPath(
 		http.MethodPost,
-		trakt.FormatURLPath("/comments/%s/replies", id),
+		trakt.NewPath("/comments/%s/replies", id),
 		&wrappedUpdateCommentParams{UpdateCommentParams: params},
 		&com,
 	)
@@ -249,8 +249,8 @@ func AddLike(id int64, p *trakt.Params) error { return getC().AddLike(id, p) }
 // Votes help determine popular comments. Only one like is allowed per comment per user.
 //
 //  - OAuth Required
-func (c *client) AddLike(id int64, params *trakt.Params) error {
-	return c.b.Call(http.MethodPost, trakt.FormatURLPath("/comments/%s/like", id), params, nil)
+func (c *Client) AddLike(id int64, params *trakt.Params) error {
+	return c.b.CallPath(http.MethodPost, trakt.NewPath("/comments/%s/like", id), params, nil)
 }
 
 // RemoveLike attempts to remove as like on a comment.
@@ -261,8 +261,8 @@ func RemoveLike(id int64, p *trakt.Params) error { return getC().RemoveLike(id,
 // RemoveLike attempts to remove as like on a comment.
 //
 //  - OAuth Required
-func (c *client) RemoveLike(id int64, params *trakt.Params) error {
-	return c.b.Call(http.MethodDelete, trakt.FormatURLPath("/comments/%s/like", id), params, nil)
+func (c *Client) RemoveLike(id int64, params *trakt.Params) error {
+	return c.b.CallPath(http.MethodDelete, trakt.NewPath("/comments/%s/like", id), params, nil)
 }
 
 // wrappedPostCommentParams wrapped post comment params which
@@ -311,7 +311,7 @@ func (commentErrorHandler) Code(statusCode int) trakt.ErrorCode {
 // - Recent
 // - Updates
 // as the only thing that changes is the action that is called in terms of arguments.
-func (c *client) generateIterator(act string, p *trakt.TrendingCommentParams) *trakt.CommentWithMediaElementIterator {
+func (c *Client) generateIterator(act string, p *trakt.TrendingCommentParams) *trakt.CommentWithMediaElementIterator {
 	var ct, mt = trakt.All, trakt.All
 	if p.MediaType != "" {
 		mt = string(p.MediaType)
@@ -320,9 +320,13 @@ func (c *client) generateIterator(act string, p *trakt.TrendingCommentParams) *t
 		mt = string(p.CommentType)
 	}
 
-	path := trakt.FormatURLPath("/comments/%s/%s/%s", act, ct, mt)
-	return &trakt.CommentWithMediaElementIterator{Iterator: c.b.NewIterator(http.MethodGet, path, p)}
+	path := trakt.NewPath("/comments/%s/%s/%s", act, ct, mt)
+	return &trakt.CommentWithMediaElementIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, p)}
 }
 
+// NewClient initialises a new comment client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new comment client from the current backend configuration.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -19,3 +19,6 @@ func (c *CountryIterator) Country() (*Country, error) {
 	rcv := &Country{}
 	return rcv, c.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (c *CountryIterator) Iter() *Iter[*Country] { return newIter(c, c.Country) }
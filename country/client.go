@@ -7,8 +7,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client the country client.
-type client struct{ b trakt.BaseClient }
+// Client the country client.
+type Client struct{ b trakt.PathClient }
 
 // List retrieves a list of all countries, including names and codes. Only TypeMovie and TypeShow are supported.
 func List(params *trakt.ListByTypeParams) *trakt.CountryIterator {
@@ -16,10 +16,10 @@ func List(params *trakt.ListByTypeParams) *trakt.CountryIterator {
 }
 
 // List retrieves a list of all countries, including names and codes. Only TypeMovie and TypeShow are supported.
-func (c *client) List(params *trakt.ListByTypeParams) *trakt.CountryIterator {
-	path := trakt.FormatURLPath("/countries/%s", params.Type.Plural())
+func (c *Client) List(params *trakt.ListByTypeParams) *trakt.CountryIterator {
+	path := trakt.NewPath("/countries/%s", params.Type.Plural())
 	return &trakt.CountryIterator{
-		BasicIterator: c.b.NewSimulatedIteratorWithCondition(http.MethodGet, path, params, func() error {
+		BasicIterator: c.b.NewSimulatedIteratorWithConditionPath(http.MethodGet, path, params, func() error {
 			switch params.Type {
 			case trakt.TypeMovie, trakt.TypeShow:
 				return nil
@@ -28,12 +28,16 @@ func (c *client) List(params *trakt.ListByTypeParams) *trakt.CountryIterator {
 			return &trakt.Error{
 				HTTPStatusCode: http.StatusUnprocessableEntity,
 				Body:           "invalid type: only movie / show are applicable",
-				Resource:       path,
+				Resource:       path.Path,
 				Code:           trakt.ErrorCodeValidationError,
 			}
 		}),
 	}
 }
 
+// NewClient initialises a new country client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC retrieves an instance of a country client.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
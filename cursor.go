@@ -0,0 +1,137 @@
+package trakt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/go-querystring/query"
+)
+
+// endpoint the endpoint an iterator retrieves its results from.
+type endpoint struct {
+	method string
+	path   string
+}
+
+// Cursor is a position in the results of an iterator, which can be saved and used to resume
+// iterating from the same position later. A cursor is retrieved using Cursor on an iterator
+// and is opaque, it can be serialised using MarshalText, or String, and restored using ParseCursor:
+//
+//  it := sync.History(params)
+//  for it.Next() {
+//  	...
+//  	saved = it.Cursor().String()
+//  }
+//
+// To resume, the cursor is set on the list params supplied to the same function with the same
+// params it was retrieved from. The iterator continues with the entry after the one which was
+// current when the cursor was retrieved:
+//
+//  c, err := trakt.ParseCursor(saved)
+//  if err != nil {
+//  	return err
+//  }
+//
+//  params.Cursor = c
+//  it := sync.History(params)
+//
+// If the cursor was retrieved from a different endpoint or with different params, the iterator
+// fails with an error matching ErrInvalidCursor.
+type Cursor struct {
+	c cursor
+}
+
+// cursor the serialised representation of a cursor.
+type cursor struct {
+	// Method the HTTP method of the endpoint.
+	Method string `json:"m"`
+	// Path the path of the endpoint.
+	Path string `json:"p"`
+	// Query the encoded params, excluding pagination.
+	Query string `json:"q,omitempty"`
+	// Page the page which was current.
+	Page int64 `json:"pg,omitempty"`
+	// Limit the amount of entries on each page.
+	Limit int64 `json:"l,omitempty"`
+	// Offset the amount of entries consumed from the page.
+	Offset int `json:"o,omitempty"`
+}
+
+// ParseCursor parses a cursor serialised using String or MarshalText.
+func ParseCursor(s string) (*Cursor, error) {
+	c := &Cursor{}
+	return c, c.UnmarshalText([]byte(s))
+}
+
+// String returns the serialised cursor.
+func (c *Cursor) String() string {
+	b, _ := c.MarshalText()
+	return string(b)
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (c *Cursor) MarshalText() ([]byte, error) {
+	b, err := json.Marshal(c.c)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, base64.RawURLEncoding.EncodedLen(len(b)))
+	base64.RawURLEncoding.Encode(out, b)
+	return out, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (c *Cursor) UnmarshalText(text []byte) error {
+	b := make([]byte, base64.RawURLEncoding.DecodedLen(len(text)))
+	n, err := base64.RawURLEncoding.Decode(b, text)
+	if err == nil {
+		err = json.Unmarshal(b[:n], &c.c)
+	}
+
+	if err != nil {
+		return &Error{Code: ErrorCodeInvalidCursor, Body: "cursor is malformed", Err: err}
+	}
+
+	return nil
+}
+
+// newCursor generates a cursor for the position in the results of an endpoint.
+func newCursor(e endpoint, p ListParamsContainer, offset int) *Cursor {
+	page, limit := p.pagination()
+	return &Cursor{c: cursor{
+		Method: e.method,
+		Path:   e.path,
+		Query:  encodeQuery(p),
+		Page:   page,
+		Limit:  limit,
+		Offset: offset,
+	}}
+}
+
+// resume validates that the cursor was retrieved from the endpoint using the same params.
+func (c *Cursor) resume(e endpoint, p ListParamsContainer) error {
+	if c.c.Method == e.method && c.c.Path == e.path && c.c.Query == encodeQuery(p) {
+		return nil
+	}
+
+	return &Error{
+		HTTPStatusCode: http.StatusUnprocessableEntity,
+		Resource:       e.path,
+		Code:           ErrorCodeInvalidCursor,
+		Body:           "cursor was retrieved from a different endpoint or with different params",
+	}
+}
+
+// encodeQuery encodes the params as they are sent in the query string, excluding the pagination.
+func encodeQuery(p ListParamsContainer) string {
+	uv, err := query.Values(p)
+	if err != nil {
+		return ""
+	}
+
+	uv.Del("page")
+	uv.Del("limit")
+	return uv.Encode()
+}
@@ -0,0 +1,112 @@
+package trakt_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/sync"
+	"github.com/jacklaaa89/trakt/trakttest"
+)
+
+// historyEntry a history entry, only decoding the title of the movie.
+type historyEntry struct {
+	Movie struct {
+		Title string `json:"title"`
+	} `json:"movie"`
+}
+
+// iterateHistory consumes up to n entries from the history of the user, n < 0 consumes every entry.
+// The titles consumed are returned with the cursor of the last entry consumed.
+func iterateHistory(srv *trakttest.Server, params *trakt.ListHistoryParams, n int) ([]string, *trakt.Cursor, error) {
+	it := sync.NewClient(trakt.New("client-id", srv.BackendConfig())).History(params)
+
+	var titles []string
+	var cursor *trakt.Cursor
+	for n != 0 && it.Next() {
+		rcv := &historyEntry{}
+		if err := it.Scan(rcv); err != nil {
+			return titles, cursor, err
+		}
+
+		titles, cursor = append(titles, rcv.Movie.Title), it.Cursor()
+		n--
+	}
+
+	return titles, cursor, it.Err()
+}
+
+func TestCursor_Resume(t *testing.T) {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	token := srv.Authorize("sean")
+	want := seedHistory(t, srv, token, 12)
+
+	params := func() *trakt.ListHistoryParams {
+		return &trakt.ListHistoryParams{ListParams: trakt.ListParams{OAuth: token, Limit: trakt.Int64(5)}}
+	}
+
+	tests := []struct {
+		name     string
+		consumed int
+		resume   func(p *trakt.ListHistoryParams)
+		err      bool
+	}{
+		{name: "mid page", consumed: 3},
+		{name: "page boundary", consumed: 5},
+		{name: "last page", consumed: 11},
+		{name: "different params", consumed: 3, resume: func(p *trakt.ListHistoryParams) {
+			p.Extended = trakt.ExtendedTypeFull
+		}, err: true},
+		{name: "different endpoint", consumed: 3, resume: func(p *trakt.ListHistoryParams) {
+			p.Type = trakt.TypeMovie
+		}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c, err := iterateHistory(srv, params(), tt.consumed)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			cursor, err := trakt.ParseCursor(c.String())
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			p := params()
+			p.Cursor = cursor
+			if tt.resume != nil {
+				tt.resume(p)
+			}
+
+			titles, _, err := iterateHistory(srv, p, -1)
+			if tt.err {
+				if !errors.Is(err, trakt.ErrInvalidCursor) {
+					t.Errorf("expected %v, got %v", trakt.ErrInvalidCursor, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if !reflect.DeepEqual(titles, want[tt.consumed:]) {
+				t.Errorf("expected %v, got %v", want[tt.consumed:], titles)
+			}
+		})
+	}
+}
+
+func TestParseCursor_Malformed(t *testing.T) {
+	for _, s := range []string{"!", "bm90IGpzb24"} {
+		if _, err := trakt.ParseCursor(s); !errors.Is(err, trakt.ErrInvalidCursor) {
+			t.Errorf("expected %v for %q, got %v", trakt.ErrInvalidCursor, s, err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package trakt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// diskCache is a Cache which stores each response as a JSON encoded
+// file in a directory. Any failure to read or write an entry is treated
+// as a cache miss.
+type diskCache struct {
+	sync.RWMutex
+
+	// dir the directory entries are stored in.
+	dir string
+}
+
+// NewDiskCache initialises a new on-disk cache which stores entries in dir.
+// The directory is created if it does not exist.
+//
+// Entries are stored unencrypted. This includes the responses to requests performed using
+// an OAuth token, which contain data specific to the user such as their history, so dir
+// should only be accessible to the current user. Responses which trakt marks as private
+// are never stored on disk.
+func NewDiskCache(dir string) (Cache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &diskCache{dir: dir}, nil
+}
+
+// path generates the file path for an entry, keys are hashed so that
+// they are safe to use as a file name.
+func (d *diskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements Cache interface.
+func (d *diskCache) Get(key string) (*CachedResponse, bool) {
+	d.RLock()
+	defer d.RUnlock()
+
+	b, err := ioutil.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	r := &CachedResponse{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, false
+	}
+
+	return r, true
+}
+
+// Set implements Cache interface.
+func (d *diskCache) Set(key string, r *CachedResponse) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	// write to a temporary file first so a partially written
+	// entry is never read.
+	tmp, err := ioutil.TempFile(d.dir, "entry-*")
+	if err != nil {
+		return
+	}
+
+	_, err = tmp.Write(b)
+	if cErr := tmp.Close(); err == nil {
+		err = cErr
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+// Delete implements Cache interface.
+func (d *diskCache) Delete(key string) {
+	d.Lock()
+	defer d.Unlock()
+	_ = os.Remove(d.path(key))
+}
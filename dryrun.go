@@ -0,0 +1,85 @@
+package trakt
+
+import "net/http"
+
+// DryRunRequest is a write request which was built but not sent because dry-run was enabled.
+// Sensitive values such as access tokens and client secrets are redacted.
+type DryRunRequest struct {
+	// Method the HTTP method of the request.
+	Method string
+	// URL the full URL of the request.
+	URL string
+	// Header the headers which would have been sent.
+	Header http.Header
+	// Body the JSON encoded body which would have been sent.
+	Body []byte
+}
+
+// DryRunError is returned instead of performing a write request when dry-run is enabled,
+// either on the params of the call or on the backend configuration. It contains the request
+// which would have been sent:
+//
+//  _, err := sync.AddToHistory(&trakt.AddToHistoryParams{
+//  	Params: trakt.Params{OAuth: token, DryRun: true},
+//  	...
+//  })
+//
+//  var dr *trakt.DryRunError
+//  if errors.As(err, &dr) {
+//  	fmt.Println(dr.Request.Method, dr.Request.URL, string(dr.Request.Body))
+//  }
+//
+// A DryRunError matches ErrDryRun using errors.Is. Read requests are always performed.
+//
+// The request is returned as an error, rather than through a receiver on the params, as no
+// response is received to populate the result of the call. Returning the zero result without an
+// error would look like a write which trakt accepted but which did not change anything, and code
+// which is unaware that dry-run was enabled on the backend configuration would act on it.
+type DryRunError struct {
+	// Request the request which would have been sent.
+	Request *DryRunRequest
+	// Chunks the request for every chunk of a sync write which is split into chunks, the
+	// first chunk is also the Request. This is empty if the write was not split.
+	Chunks []*DryRunRequest
+}
+
+// Error implements error interface.
+func (d *DryRunError) Error() string {
+	return string(ErrorCodeDryRun) + ": " + d.Request.Method + " " + d.Request.URL + " was not sent"
+}
+
+// Is allows a DryRunError to match ErrDryRun using errors.Is.
+func (d *DryRunError) Is(target error) bool { return target == ErrDryRun }
+
+// dryRunner is implemented by parameters which can enable dry-run.
+type dryRunner interface {
+	// dryRun returns whether write requests should be built but not sent.
+	dryRun() bool
+}
+
+// isDryRun determines if the supplied parameters have enabled dry-run.
+func isDryRun(p ParamsContainer) bool {
+	switch r := p.(type) {
+	case dryRunner:
+		return r.dryRun()
+	}
+
+	return false
+}
+
+// newDryRunError generates the error returned in place of performing the request.
+func newDryRunError(req *http.Request, body []byte) *DryRunError {
+	h := req.Header.Clone()
+	for _, v := range h {
+		for i := range v {
+			v[i] = Redact(v[i])
+		}
+	}
+
+	var b []byte
+	if len(body) > 0 {
+		b = []byte(Redact(string(body)))
+	}
+
+	return &DryRunError{Request: &DryRunRequest{Method: req.Method, URL: req.URL.String(), Header: h, Body: b}}
+}
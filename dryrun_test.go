@@ -0,0 +1,53 @@
+package trakt
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBackend_DryRun(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		config bool
+		params *Params
+		method string
+		dryRun bool
+	}{
+		{name: "params", params: &Params{OAuth: "token", DryRun: true}, method: http.MethodPost, dryRun: true},
+		{name: "config", config: true, params: &Params{OAuth: "token"}, method: http.MethodDelete, dryRun: true},
+		{name: "read", config: true, params: &Params{OAuth: "token", DryRun: true}, method: http.MethodGet},
+		{name: "disabled", params: &Params{OAuth: "token"}, method: http.MethodPost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&hits, 0)
+			b := NewBackend(&BackendConfig{URL: srv.URL, GetRateLimit: NoRateLimit, DryRun: tt.config})
+
+			err := b.Call(tt.method, "/sync/history", "key", tt.params, &struct{}{})
+
+			var dr *DryRunError
+			if errors.As(err, &dr) != tt.dryRun || errors.Is(err, ErrDryRun) != tt.dryRun {
+				t.Fatalf("expected dry-run to be %v, got %v", tt.dryRun, err)
+			}
+
+			if sent := atomic.LoadInt32(&hits) == 1; sent == tt.dryRun {
+				t.Errorf("expected sent to be %v, got %v", !tt.dryRun, sent)
+			}
+
+			if dr != nil && dr.Request.Header.Get("Authorization") != "Bearer "+redactedValue {
+				t.Errorf("expected the token to be redacted, got %s", dr.Request.Header.Get("Authorization"))
+			}
+		})
+	}
+}
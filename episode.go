@@ -24,6 +24,9 @@ func (e *EpisodeIterator) Episode() (*Episode, error) {
 	return rcv, e.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (e *EpisodeIterator) Iter() *Iter[*Episode] { return newIter(e, e.Episode) }
+
 type EpisodeWithTranslations struct {
 	Episode
 	Translations []*Translation `json:"translations"`
@@ -35,3 +38,8 @@ func (e *EpisodeWithTranslationsIterator) Episode() (*EpisodeWithTranslations, e
 	rcv := &EpisodeWithTranslations{}
 	return rcv, e.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (e *EpisodeWithTranslationsIterator) Iter() *Iter[*EpisodeWithTranslations] {
+	return newIter(e, e.Episode)
+}
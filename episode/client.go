@@ -7,9 +7,9 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client is a representation of a episode client, capable of
+// Client is a representation of a episode client, capable of
 // retrieving information on specific show episodes.
-type client struct{ b trakt.BaseClient }
+type Client struct{ b trakt.PathClient }
 
 // Get returns a single episode's details. All date and times are in UTC and were calculated using the
 // episode's air_date and show's country and air_time.
@@ -27,10 +27,10 @@ func Get(id trakt.SearchID, season, episode int64, params *trakt.ExtendedParams)
 // Note: If the first_aired is unknown, it will be set to null.
 //
 //  - Extended Info
-func (c *client) Get(id trakt.SearchID, season, episode int64, params *trakt.ExtendedParams) (*trakt.Episode, error) {
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s/episodes/%s", id, season, episode)
+func (c *Client) Get(id trakt.SearchID, season, episode int64, params *trakt.ExtendedParams) (*trakt.Episode, error) {
+	path := trakt.NewPath("/shows/%s/seasons/%s/episodes/%s", id, season, episode)
 	ep := &trakt.Episode{}
-	err := c.b.Call(http.MethodGet, path, params, ep)
+	err := c.b.CallPath(http.MethodGet, path, params, ep)
 	return ep, err
 }
 
@@ -47,16 +47,16 @@ func Translations(
 
 // Translations returns all translations for an episode, including language and translated
 // values for title and overview.
-func (c *client) Translations(
+func (c *Client) Translations(
 	id trakt.SearchID,
 	season, episode int64,
 	params *trakt.TranslationListParams,
 ) *trakt.TranslationIterator {
 
-	path := trakt.FormatURLPath(
+	path := trakt.NewPath(
 		"/shows/%s/seasons/%s/episodes/%s/translations/%s", id, season, episode, params.Language,
 	)
-	return &trakt.TranslationIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+	return &trakt.TranslationIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
 // Comments Returns all top level comments for an episode. By default, the newest comments are returned first.
@@ -71,17 +71,17 @@ func Comments(id trakt.SearchID, season, episode int64, params *trakt.CommentLis
 // Other sorting options include oldest, most likes, most replies, highest rated, lowest rated, and most plays.
 //
 //  - Pagination
-func (c *client) Comments(
+func (c *Client) Comments(
 	id trakt.SearchID,
 	season, episode int64,
 	params *trakt.CommentListParams,
 ) *trakt.CommentIterator {
 
-	path := trakt.FormatURLPath(
+	path := trakt.NewPath(
 		"shows/%s/seasons/%s/episodes/%s/comments/%s",
 		id, season, episode, params.Sort,
 	)
-	return &trakt.CommentIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+	return &trakt.CommentIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Lists returns all lists that contain this episode. By default, personal lists are returned
@@ -96,12 +96,12 @@ func Lists(id trakt.SearchID, season, episode int64, params *trakt.GetListParams
 // sorted by the most popular.
 //
 //  - Pagination
-func (c *client) Lists(id trakt.SearchID, season, episode int64, params *trakt.GetListParams) *trakt.ListIterator {
-	path := trakt.FormatURLPath(
+func (c *Client) Lists(id trakt.SearchID, season, episode int64, params *trakt.GetListParams) *trakt.ListIterator {
+	path := trakt.NewPath(
 		"/shows/%s/seasons/%s/episodes/%s/lists/%s/%s",
 		id, season, episode, params.ListType, params.SortType,
 	)
-	return &trakt.ListIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+	return &trakt.ListIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // People returns all cast and crew for an episode.
@@ -138,15 +138,15 @@ func People(id trakt.SearchID, season, episode int64, params *trakt.ExtendedPara
 // Note: This returns a lot of data, so please only use this extended parameter if you actually need it!
 //
 //  - Extended Info
-func (c *client) People(
+func (c *Client) People(
 	id trakt.SearchID,
 	season, episode int64,
 	params *trakt.ExtendedParams,
 ) (*trakt.CastAndCrew, error) {
 
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s/episodes/%s/people", id, season, episode)
+	path := trakt.NewPath("/shows/%s/seasons/%s/episodes/%s/people", id, season, episode)
 	cc := &trakt.CastAndCrew{}
-	err := c.b.Call(http.MethodGet, path, params, cc)
+	err := c.b.CallPath(http.MethodGet, path, params, cc)
 	return cc, err
 }
 
@@ -156,15 +156,15 @@ func Ratings(id trakt.SearchID, season, episode int64, params *trakt.BasicParams
 }
 
 // Ratings returns the rating (between 0 and 10) and distribution for an episode.
-func (c *client) Ratings(
+func (c *Client) Ratings(
 	id trakt.SearchID,
 	season, episode int64,
 	params *trakt.BasicParams,
 ) (*trakt.RatingDistribution, error) {
 
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s/episodes/%s/ratings", id, season, episode)
+	path := trakt.NewPath("/shows/%s/seasons/%s/episodes/%s/ratings", id, season, episode)
 	r := &trakt.RatingDistribution{}
-	err := c.b.Call(http.MethodGet, path, params, r)
+	err := c.b.CallPath(http.MethodGet, path, params, r)
 	return r, err
 }
 
@@ -174,15 +174,15 @@ func Statistics(id trakt.SearchID, season, episode int64, params *trakt.BasicPar
 }
 
 // Statistics returns lots of episode stats.
-func (c *client) Statistics(
+func (c *Client) Statistics(
 	id trakt.SearchID,
 	season, episode int64,
 	params *trakt.BasicParams,
 ) (*trakt.Statistics, error) {
 
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s/episodes/%s/stats", id, season, episode)
+	path := trakt.NewPath("/shows/%s/seasons/%s/episodes/%s/stats", id, season, episode)
 	stats := &trakt.Statistics{}
-	err := c.b.Call(http.MethodGet, path, params, stats)
+	err := c.b.CallPath(http.MethodGet, path, params, stats)
 	return stats, err
 }
 
@@ -196,15 +196,19 @@ func WatchingNow(id trakt.SearchID, season, episode int64, params *trakt.BasicLi
 // WatchingNow returns all users watching this episode right now.
 //
 //  - Extended Info
-func (c *client) WatchingNow(
+func (c *Client) WatchingNow(
 	id trakt.SearchID,
 	season, episode int64,
 	params *trakt.BasicListParams,
 ) *trakt.UserIterator {
 
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s/episodes/%s/watching", id, season, episode)
-	return &trakt.UserIterator{Iterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+	path := trakt.NewPath("/shows/%s/seasons/%s/episodes/%s/watching", id, season, episode)
+	return &trakt.UserIterator{Iterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
+// NewClient initialises a new episode client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new episode client with the current backend configuration.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -2,11 +2,17 @@ package trakt
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 )
 
 type ErrorCode string
 
+// Error implements the error interface, this allows an ErrorCode to be used
+// as a sentinel error which can be compared against using errors.Is.
+func (c ErrorCode) Error() string { return string(c) }
+
 const (
 	ErrorCodeUnknownError ErrorCode = "unknown_error"
 
@@ -40,6 +46,54 @@ const (
 	// Error codes for miscellaneous errors from within the SDK.
 	ErrorCodeEmptyFrameData ErrorCode = "empty_frame_data"
 	ErrorCodeEncodingError  ErrorCode = "encoding_error"
+	ErrorCodeNetworkError   ErrorCode = "network_error"
+	ErrorCodeCircuitOpen    ErrorCode = "circuit_open"
+	ErrorCodeDryRun         ErrorCode = "dry_run"
+	ErrorCodeInvalidCursor  ErrorCode = "invalid_cursor"
+)
+
+// Sentinel errors for each ErrorCode, these can be used with errors.Is to determine
+// the type of error which occurred:
+//
+//  if errors.Is(err, trakt.ErrNotFound) {
+//  	...
+//  }
+//
+// An error will also match the sentinel for the generic code of its HTTP status, i.e
+// an error with the code ErrorCodeCheckinInProgress will also match ErrConflict.
+var (
+	ErrUnknown = error(ErrorCodeUnknownError)
+
+	ErrInvalidRequest     = error(ErrorCodeInvalidRequest)
+	ErrForbidden          = error(ErrorCodeForbidden)
+	ErrUnauthorized       = error(ErrorCodeUnauthorized)
+	ErrNotFound           = error(ErrorCodeNotFound)
+	ErrInvalidOperation   = error(ErrorCodeInvalidOperation)
+	ErrConflict           = error(ErrorCodeConflict)
+	ErrInvalidContentType = error(ErrorCodeInvalidContentType)
+	ErrValidation         = error(ErrorCodeValidationError)
+	ErrRateLimitExceeded  = error(ErrorCodeRateLimitExceeded)
+	ErrServerError        = error(ErrorCodeServerError)
+	ErrServerUnavailable  = error(ErrorCodeServerUnavailable)
+
+	ErrPendingDeviceCode = error(ErrorCodePendingDeviceCode)
+	ErrInvalidDeviceCode = error(ErrorCodeInvalidDeviceCode)
+	ErrDeviceCodeUsed    = error(ErrorCodeDeviceCodeUsed)
+	ErrDeviceCodeExpired = error(ErrorCodeDeviceCodeExpired)
+	ErrDeviceCodeDenied  = error(ErrorCodeDeviceCodeDenied)
+
+	ErrPostInvalidUser        = error(ErrorCodePostInvalidUser)
+	ErrPostInvalidItem        = error(ErrorCodePostInvalidItem)
+	ErrCommentCannotBeRemoved = error(ErrorCodeCommentCannotBeRemoved)
+
+	ErrCheckinInProgress = error(ErrorCodeCheckinInProgress)
+
+	ErrEmptyFrameData = error(ErrorCodeEmptyFrameData)
+	ErrEncoding       = error(ErrorCodeEncodingError)
+	ErrNetwork        = error(ErrorCodeNetworkError)
+	ErrCircuitOpen    = error(ErrorCodeCircuitOpen)
+	ErrDryRun         = error(ErrorCodeDryRun)
+	ErrInvalidCursor  = error(ErrorCodeInvalidCursor)
 )
 
 // DefaultErrorHandler the default error handler which is used to determine
@@ -61,6 +115,17 @@ type Error struct {
 	Body string `json:"body"`
 	// Code the error code attached to the error.
 	Code ErrorCode `json:"code"`
+	// Message the error parsed from the response body, if trakt supplied one.
+	Message string `json:"error,omitempty"`
+	// Description the description of the error parsed from the response body,
+	// if trakt supplied one.
+	Description string `json:"error_description,omitempty"`
+	// RetryAfter how long trakt has asked us to wait before performing another request.
+	RetryAfter time.Duration `json:"-"`
+	// Header the response headers.
+	Header http.Header `json:"-"`
+	// Err the underlying error, this is set when the request failed to receive a response.
+	Err error `json:"-"`
 }
 
 // Error serializes the error object to JSON and returns it as a string.
@@ -69,6 +134,50 @@ func (e *Error) Error() string {
 	return string(ret)
 }
 
+// Unwrap returns the underlying error, if any.
+func (e *Error) Unwrap() error { return e.Err }
+
+// Is implements the interface used by errors.Is, an error matches the sentinel
+// for its code and the sentinel for the generic code of its HTTP status.
+func (e *Error) Is(target error) bool {
+	c, ok := target.(ErrorCode)
+	if !ok {
+		return false
+	}
+
+	if e.Code == c {
+		return true
+	}
+
+	return e.HTTPStatusCode != 0 && c != ErrorCodeUnknownError && DefaultErrorHandler.Code(e.HTTPStatusCode) == c
+}
+
+// errorBody the body trakt responds with for some errors.
+type errorBody struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// newNetworkError wraps an error from a request which failed to receive a response.
+func newNetworkError(resource string, err error) *Error {
+	return &Error{Resource: resource, Body: err.Error(), Code: ErrorCodeNetworkError, Err: err}
+}
+
+// isNetworkError determines if an error occurred before a response was received from trakt.
+func isNetworkError(err error) bool {
+	// a dry-run request was never sent.
+	if errors.Is(err, ErrDryRun) {
+		return false
+	}
+
+	var traktErr *Error
+	if !errors.As(err, &traktErr) {
+		return true
+	}
+
+	return traktErr.Code == ErrorCodeNetworkError
+}
+
 type defaultErrorHandler struct{}
 
 // Code default error handler which maps HTTP status codes
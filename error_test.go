@@ -0,0 +1,162 @@
+package trakt
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// sentinels every ErrorCode and the sentinel error which is exported for it.
+var sentinels = map[ErrorCode]error{
+	ErrorCodeUnknownError:           ErrUnknown,
+	ErrorCodeInvalidRequest:         ErrInvalidRequest,
+	ErrorCodeForbidden:              ErrForbidden,
+	ErrorCodeUnauthorized:           ErrUnauthorized,
+	ErrorCodeNotFound:               ErrNotFound,
+	ErrorCodeInvalidOperation:       ErrInvalidOperation,
+	ErrorCodeConflict:               ErrConflict,
+	ErrorCodeInvalidContentType:     ErrInvalidContentType,
+	ErrorCodeValidationError:        ErrValidation,
+	ErrorCodeRateLimitExceeded:      ErrRateLimitExceeded,
+	ErrorCodeServerError:            ErrServerError,
+	ErrorCodeServerUnavailable:      ErrServerUnavailable,
+	ErrorCodePendingDeviceCode:      ErrPendingDeviceCode,
+	ErrorCodeInvalidDeviceCode:      ErrInvalidDeviceCode,
+	ErrorCodeDeviceCodeUsed:         ErrDeviceCodeUsed,
+	ErrorCodeDeviceCodeExpired:      ErrDeviceCodeExpired,
+	ErrorCodeDeviceCodeDenied:       ErrDeviceCodeDenied,
+	ErrorCodePostInvalidUser:        ErrPostInvalidUser,
+	ErrorCodePostInvalidItem:        ErrPostInvalidItem,
+	ErrorCodeCommentCannotBeRemoved: ErrCommentCannotBeRemoved,
+	ErrorCodeCheckinInProgress:      ErrCheckinInProgress,
+	ErrorCodeEmptyFrameData:         ErrEmptyFrameData,
+	ErrorCodeEncodingError:          ErrEncoding,
+	ErrorCodeNetworkError:           ErrNetwork,
+	ErrorCodeCircuitOpen:            ErrCircuitOpen,
+	ErrorCodeDryRun:                 ErrDryRun,
+	ErrorCodeInvalidCursor:          ErrInvalidCursor,
+}
+
+func TestError_Is(t *testing.T) {
+	for code, sentinel := range sentinels {
+		t.Run(string(code), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &Error{Code: code})
+			if !errors.Is(err, sentinel) {
+				t.Errorf("expected %v to match %v", err, sentinel)
+			}
+
+			// an error only matches the sentinel for its own code.
+			for other, s := range sentinels {
+				if other != code && errors.Is(err, s) {
+					t.Errorf("expected %v not to match %v", err, s)
+				}
+			}
+		})
+	}
+}
+
+func TestError_IsStatus(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   *Error
+		match []error
+		miss  []error
+	}{
+		{
+			name:  "specific code",
+			err:   &Error{HTTPStatusCode: http.StatusConflict, Code: ErrorCodeCheckinInProgress},
+			match: []error{ErrCheckinInProgress, ErrConflict},
+			miss:  []error{ErrNotFound, ErrUnknown},
+		},
+		{
+			name:  "cloudflare",
+			err:   &Error{HTTPStatusCode: 520, Code: ErrorCodeServerUnavailable},
+			match: []error{ErrServerUnavailable},
+			miss:  []error{ErrServerError},
+		},
+		{
+			name:  "unknown status",
+			err:   &Error{HTTPStatusCode: http.StatusTeapot, Code: ErrorCodeEncodingError},
+			match: []error{ErrEncoding},
+			miss:  []error{ErrUnknown},
+		},
+		{
+			name: "not an error code",
+			err:  &Error{HTTPStatusCode: http.StatusNotFound, Code: ErrorCodeNotFound},
+			miss: []error{errors.New(string(ErrorCodeNotFound))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, target := range tt.match {
+				if !errors.Is(tt.err, target) {
+					t.Errorf("expected %v to match %v", tt.err, target)
+				}
+			}
+
+			for _, target := range tt.miss {
+				if errors.Is(tt.err, target) {
+					t.Errorf("expected %v not to match %v", tt.err, target)
+				}
+			}
+		})
+	}
+}
+
+func TestError_As(t *testing.T) {
+	want := &Error{HTTPStatusCode: http.StatusNotFound, Code: ErrorCodeNotFound, Resource: "/movies/tron"}
+	err := fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", want))
+
+	var traktErr *Error
+	if !errors.As(err, &traktErr) {
+		t.Fatalf("expected %v to contain an *Error", err)
+	}
+
+	if traktErr != want {
+		t.Errorf("expected %v, got %v", want, traktErr)
+	}
+}
+
+func TestError_UnwrapNetwork(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "no such host", Name: "api.trakt.tv", IsNotFound: true}
+	err := newNetworkError("/movies/trending", dnsErr)
+
+	if !isNetworkError(err) {
+		t.Errorf("expected %v to be a network error", err)
+	}
+
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("expected %v to match %v", err, ErrNetwork)
+	}
+
+	var rcv *net.DNSError
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &rcv) || rcv != dnsErr {
+		t.Errorf("expected the DNS error to be unwrapped, got %v", rcv)
+	}
+
+	if isNetworkError(&Error{HTTPStatusCode: http.StatusNotFound, Code: ErrorCodeNotFound}) {
+		t.Error("expected an error response not to be a network error")
+	}
+}
+
+func TestBackend_NetworkError(t *testing.T) {
+	// a server which is closed straight away so the connection is refused.
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	b := NewBackend(&BackendConfig{URL: srv.URL, GetRateLimit: NoRateLimit})
+	err := b.Call(http.MethodGet, "/movies/trending", "key", &BasicParams{}, nil)
+
+	if !errors.Is(err, ErrNetwork) || !isNetworkError(err) {
+		t.Fatalf("expected %v, got %v", ErrNetwork, err)
+	}
+
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		t.Errorf("expected the underlying *net.OpError to be unwrapped from %v", err)
+	}
+}
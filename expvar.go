@@ -0,0 +1,111 @@
+package trakt
+
+import (
+	"expvar"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ExpvarObserver is an Observer which records metrics for each endpoint using expvar. Metrics
+// are keyed by the method and path template of the endpoint, i.e "GET /shows/%s/seasons", and
+// each endpoint records the following counters:
+//
+//  requests        the amount of calls made to the endpoint.
+//  retries         the amount of retries performed.
+//  errors          the amount of calls which failed.
+//  decode_errors   the amount of responses which could not be decoded.
+//  cached          the amount of calls served from the cache.
+//  status_<code>   the amount of calls which completed with the status code.
+//  duration_ns     the total duration of every call in nanoseconds.
+//
+// The state of the circuit breaker, if one is configured, is recorded under "circuit_state"
+// and the amount of times the circuit has transitioned into each state is recorded under
+// "circuit_<state>", i.e "circuit_open".
+//
+// An ExpvarObserver is also an expvar.Var so can be published under any name.
+type ExpvarObserver struct {
+	mu sync.Mutex
+	m  *expvar.Map
+}
+
+// NewExpvarObserver generates a new ExpvarObserver. If name is not empty, the metrics are
+// published using expvar under that name, like expvar.Publish this panics if the name is
+// already in use.
+func NewExpvarObserver(name string) *ExpvarObserver {
+	o := &ExpvarObserver{m: new(expvar.Map).Init()}
+	if name != "" {
+		expvar.Publish(name, o)
+	}
+
+	return o
+}
+
+// String implements expvar.Var interface.
+func (o *ExpvarObserver) String() string { return o.m.String() }
+
+// Endpoint retrieves the metrics for the endpoint with the supplied method and path template.
+// This is nil if no calls have been made to the endpoint.
+func (o *ExpvarObserver) Endpoint(method, pathTemplate string) *expvar.Map {
+	m, _ := o.m.Get(method + " " + pathTemplate).(*expvar.Map)
+	return m
+}
+
+// RequestStarted implements Observer interface.
+func (o *ExpvarObserver) RequestStarted(r *RequestInfo) { o.endpoint(r).Add("requests", 1) }
+
+// RequestRetried implements Observer interface.
+func (o *ExpvarObserver) RequestRetried(r *RequestInfo, _ *RetryAttempt, _ time.Duration) {
+	o.endpoint(r).Add("retries", 1)
+}
+
+// DecodeFailed implements Observer interface.
+func (o *ExpvarObserver) DecodeFailed(r *RequestInfo, _ error) { o.endpoint(r).Add("decode_errors", 1) }
+
+// ResponseReceived implements Observer interface.
+func (o *ExpvarObserver) ResponseReceived(r *RequestInfo, res *ResponseInfo) {
+	m := o.endpoint(r)
+	m.Add("duration_ns", int64(res.Duration))
+
+	if res.StatusCode != 0 {
+		m.Add("status_"+strconv.Itoa(res.StatusCode), 1)
+	}
+
+	if res.Cached {
+		m.Add("cached", 1)
+	}
+
+	if res.Err != nil {
+		m.Add("errors", 1)
+	}
+}
+
+// CircuitStateChanged implements CircuitObserver interface.
+func (o *ExpvarObserver) CircuitStateChanged(_, to CircuitState) {
+	state := new(expvar.String)
+	state.Set(to.String())
+
+	o.m.Set("circuit_state", state)
+	o.m.Add("circuit_"+to.String(), 1)
+}
+
+// endpoint retrieves the metrics for the endpoint the request was made to, creating
+// them if required.
+func (o *ExpvarObserver) endpoint(r *RequestInfo) *expvar.Map {
+	key := r.Method + " " + r.PathTemplate
+	if m, ok := o.m.Get(key).(*expvar.Map); ok {
+		return m
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	// another call could have created the metrics while we were waiting.
+	if m, ok := o.m.Get(key).(*expvar.Map); ok {
+		return m
+	}
+
+	m := new(expvar.Map).Init()
+	o.m.Set(key, m)
+	return m
+}
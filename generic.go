@@ -218,6 +218,14 @@ func (li *GenericMediaElementIterator) Movie() (*Movie, error) {
 	return cur.Movie, nil
 }
 
+// Iter returns the typed variant of the iterator.
+func (li *GenericMediaElementIterator) Iter() *Iter[*GenericMediaElement] {
+	return newIter(li, func() (*GenericMediaElement, error) {
+		rcv := &GenericMediaElement{}
+		return rcv, li.Scan(rcv)
+	})
+}
+
 type GenericElement struct {
 	GenericMediaElement
 
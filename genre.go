@@ -11,3 +11,6 @@ func (c *GenreIterator) Genre() (*Genre, error) {
 	rcv := &Genre{}
 	return rcv, c.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (c *GenreIterator) Iter() *Iter[*Genre] { return newIter(c, c.Genre) }
@@ -7,8 +7,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client the genre client.
-type client struct{ b trakt.BaseClient }
+// Client the genre client.
+type Client struct{ b trakt.PathClient }
 
 // List retrieves a list of all genres, including names and slugs.
 func List(params *trakt.ListByTypeParams) *trakt.GenreIterator {
@@ -16,10 +16,10 @@ func List(params *trakt.ListByTypeParams) *trakt.GenreIterator {
 }
 
 // List retrieves a list of all genres, including names and slugs.
-func (c *client) List(params *trakt.ListByTypeParams) *trakt.GenreIterator {
-	path := trakt.FormatURLPath("/genres/%s", params.Type)
+func (c *Client) List(params *trakt.ListByTypeParams) *trakt.GenreIterator {
+	path := trakt.NewPath("/genres/%s", params.Type)
 	return &trakt.GenreIterator{
-		BasicIterator: c.b.NewSimulatedIteratorWithCondition(http.MethodGet, path, params, func() error {
+		BasicIterator: c.b.NewSimulatedIteratorWithConditionPath(http.MethodGet, path, params, func() error {
 			switch params.Type {
 			case trakt.TypeMovie, trakt.TypeShow:
 				return nil
@@ -28,12 +28,16 @@ func (c *client) List(params *trakt.ListByTypeParams) *trakt.GenreIterator {
 			return &trakt.Error{
 				HTTPStatusCode: http.StatusUnprocessableEntity,
 				Body:           "invalid type: only movie / show are applicable",
-				Resource:       path,
+				Resource:       path.Path,
 				Code:           trakt.ErrorCodeValidationError,
 			}
 		}),
 	}
 }
 
+// NewClient initialises a new genre client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC retrieves an instance of a genre client.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
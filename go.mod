@@ -1,6 +1,6 @@
 module github.com/jacklaaa89/trakt
 
-go 1.14
+go 1.18
 
 require (
 	github.com/davecgh/go-spew v1.1.1
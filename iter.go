@@ -29,11 +29,33 @@ type listMeta struct {
 
 func (l *listMeta) meta() *listMeta { return l }
 
-// unmarshalHeaders allows us to unmarshal a response from
+// Pagination is the pagination information for the page an iterator is currently on,
+// which is supplied by trakt in the X-Pagination-* headers.
+type Pagination struct {
+	// CurrentPage the page the iterator is currently on.
+	CurrentPage int64
+	// Limit the amount of items on each page.
+	Limit int64
+	// TotalPages the total amount of pages.
+	TotalPages int64
+	// TotalCount the total amount of items across all pages.
+	TotalCount int64
+}
+
+// pagination returns the exported representation of the metadata.
+func (l *listMeta) pagination() Pagination {
+	if l == nil {
+		return Pagination{}
+	}
+
+	return Pagination{CurrentPage: l.currentPage, Limit: l.limit, TotalPages: l.totalPages, TotalCount: l.totalCount}
+}
+
+// UnmarshalHeaders allows us to unmarshal a response from
 // the response HTTP headers.
 // this is the case for pagination values where they are supplied
 // in headers and not the response.
-func (f *frame) unmarshalHeaders(h http.Header) error {
+func (f *frame) UnmarshalHeaders(h http.Header) error {
 	(*f).listMeta = &listMeta{
 		limit:       parseInt(h.Get(`X-Pagination-limit`)),
 		currentPage: parseInt(h.Get(`X-Pagination-Page`)),
@@ -80,12 +102,16 @@ type frame struct {
 // the interface which the raw HTTP body should be unmarshalled into.
 func (f *frame) rcv() *[]*json.RawMessage { return f.r }
 
+// Receiver implements Frame interface.
+func (f *frame) Receiver() interface{} { return f.r }
+
 // headers returns the headers from the HTTP request.
 func (f *frame) headers() http.Header { return f.h }
 
 // iterationFrame represents a window or slice of
 // an entire pagination result.
 type iterationFrame interface {
+	Frame
 	// rcv returns the receiver which the response data is unmarshalled into.
 	rcv() *[]*json.RawMessage
 	// meta the current metadata for the position we are in the complete set.
@@ -107,6 +133,9 @@ type BasicIterator interface {
 	Next() bool
 	// Scan scans the current data into the supplied receiver.
 	Scan(rcv interface{}) error
+	// Cursor returns the position of the current entry, which can be saved and used to
+	// resume iterating from the entry after it by setting it on the list params.
+	Cursor() *Cursor
 	// getPage internal function which allows us to retrieve the next page
 	// of results.
 	getPage() iterationFrame
@@ -121,6 +150,21 @@ type Iterator interface {
 	// if the result set has less pages than the limit, obviously it will
 	// finish before that.
 	PageLimit(page int64)
+	// Prefetch enables fetching up to n pages ahead of the current page concurrently once the
+	// total amount of pages is known from the first page. Pages are still delivered in order, each
+	// request waits on the rate limit and uses the context of the params. Prefetching stops at the
+	// page limit and once a page fails, the error is returned when the iterator reaches that page.
+	// A value of zero or less disables prefetching, which is the default.
+	Prefetch(n int)
+	// Pagination returns the pagination information for the current page, i.e to show
+	// "page 3 of 40". It is available once the first page has been retrieved and is
+	// empty for iterators which do not paginate.
+	Pagination() Pagination
+	// SeekPage moves the iterator to the start of the supplied page without retrieving the pages
+	// before it, i.e to jump straight to a page selected in a UI. The next call to Next returns the
+	// first entry on the page, which is retrieved immediately unless the iterator is lazy-loaded,
+	// any previous error is cleared. This has no effect on iterators which do not paginate.
+	SeekPage(page int64)
 }
 
 // singleIter this type of iterator
@@ -154,6 +198,12 @@ type singleIter struct {
 	// WARNING on lazy-loaded iterators this will only be available after the
 	// initial call to Next.
 	initialHeaders http.Header
+	// endpoint the endpoint the results are retrieved from.
+	endpoint endpoint
+	// consumed the amount of entries consumed from the current frame.
+	consumed int
+	// skip the amount of entries to skip in the initial frame when resuming from a cursor.
+	skip int
 }
 
 // isLazyLoad returns whether lazy load is enabled on the iterator.
@@ -220,6 +270,55 @@ func (s *singleIter) Err() error {
 // with a single page. So the limit is already enforced to 1.
 func (s *singleIter) PageLimit(_ int64) {}
 
+// Prefetch is not required on a single iterator as it only deals with a single page.
+func (s *singleIter) Prefetch(_ int) {}
+
+// Pagination is not available on a single iterator as it only deals with a single page.
+func (s *singleIter) Pagination() Pagination { return Pagination{} }
+
+// SeekPage is not required on a single iterator as it only deals with a single page.
+func (s *singleIter) SeekPage(_ int64) {}
+
+// Cursor returns the position of the current entry, which can be used to resume iterating
+// from the entry after it.
+func (s *singleIter) Cursor() *Cursor {
+	s.RLock()
+	defer s.RUnlock()
+	return newCursor(s.endpoint, s.listParams, s.consumed+s.skip)
+}
+
+// setValues sets the entries of a new frame, skipping any entries which were consumed before
+// resuming from a cursor. The lock must be held when calling.
+func (s *singleIter) setValues(v []*json.RawMessage) {
+	skip := s.skip
+	if skip > len(v) {
+		skip = len(v)
+	}
+
+	s.values, s.consumed, s.skip = v[skip:], skip, 0
+}
+
+// resume prepares the iterator to resume from the cursor defined on the params, if any.
+// false is returned if the cursor is invalid, in which case the error is set on the iterator.
+func (s *singleIter) resume() bool {
+	c := s.listParams.cursor()
+	if c == nil {
+		return true
+	}
+
+	if err := c.resume(s.endpoint, s.listParams); err != nil {
+		s.err, s.loaded = err, true
+		return false
+	}
+
+	if c.c.Page > 0 && c.c.Limit > 0 {
+		s.listParams.setPagination(c.c.Page, c.c.Limit)
+	}
+
+	s.skip = c.c.Offset
+	return true
+}
+
 // getPage retrieves the next page using the defined query.
 func (s *singleIter) getPage() iterationFrame {
 	var window iterationFrame
@@ -238,7 +337,7 @@ func (s *singleIter) getPage() iterationFrame {
 
 		// if we have a receiver response on the frame.
 		if rcv := window.rcv(); rcv != nil {
-			s.values = *rcv
+			s.setValues(*rcv)
 		}
 
 		// if this is the initial request, set the headers.
@@ -284,6 +383,7 @@ func (s *singleIter) Next() bool {
 		}
 		s.cur = s.values[0]
 		s.values = s.values[1:]
+		s.consumed++
 		next = true
 	})
 
@@ -300,6 +400,10 @@ type iter struct {
 	singleIter
 	meta  *listMeta
 	limit int64
+	// prefetch the amount of pages to fetch ahead of the current page.
+	prefetch int
+	// ahead the pages which are being fetched ahead of the current page.
+	ahead map[int64]*prefetched
 }
 
 // PageLimit allows us to limit the amount of pages to paginate.
@@ -309,6 +413,35 @@ func (it *iter) PageLimit(page int64) {
 	it.limit = page
 }
 
+// Pagination returns the pagination information for the current page.
+func (it *iter) Pagination() Pagination {
+	it.RLock()
+	defer it.RUnlock()
+	return it.meta.pagination()
+}
+
+// SeekPage moves the iterator to the start of the supplied page, pages start at 1.
+func (it *iter) SeekPage(page int64) {
+	if page < defaultPage {
+		page = defaultPage
+	}
+
+	var lazy bool
+	it.withLock(func() {
+		_, limit := it.listParams.pagination()
+		it.listParams.setPagination(page, limit)
+
+		// discard the current page, its pagination and any pages being fetched ahead of it.
+		it.cur, it.values, it.err, it.ahead, it.meta = nil, nil, nil, nil, nil
+		it.consumed, it.skip = 0, 0
+		it.loaded, lazy = false, it.lazyLoad
+	})
+
+	if !lazy {
+		it.getPage()
+	}
+}
+
 // Next moves the cursor in the result set to the next entry
 // and returns true if there is a pointer available.
 // this function returns false if no cursors are available in
@@ -331,17 +464,25 @@ func (it *iter) Next() bool {
 	if it.isLazyLoad() && !it.hasLoaded() {
 		// perform the initial page query.
 		it.getPage()
-		return it.singleIter.Next()
+		it.withLock(func() {
+			err, lm, v = it.err, it.meta, it.values
+		})
+
+		if err != nil {
+			return false
+		}
 	}
 
 	np := lm.currentPage + 1
 	if len(v) == 0 && (lm.totalPages > lm.currentPage) && !it.hasReachedLimit(np) {
-		// update the page number.
-		it.withLock(func() {
-			it.listParams.setPagination(np, lm.limit)
-		})
+		if !it.nextPrefetched(np) {
+			// update the page number.
+			it.withLock(func() {
+				it.listParams.setPagination(np, lm.limit)
+			})
 
-		it.getPage()
+			it.getPage()
+		}
 	}
 
 	return it.singleIter.Next()
@@ -364,6 +505,7 @@ func (it *iter) getPage() iterationFrame {
 	if window != nil {
 		it.withLock(func() {
 			it.meta = window.meta()
+			it.schedule()
 		})
 	}
 
@@ -377,10 +519,11 @@ type queryFunc func(ListParamsContainer) (iterationFrame, error)
 //
 // lazyLoad determines if the initial page of results is loaded when the iterator is generated
 // or when we call our initial call to Next.
-func newIterator(p ListParamsContainer, query queryFunc, lazyLoad bool) Iterator {
+func newIterator(p ListParamsContainer, e endpoint, query queryFunc, lazyLoad bool) Iterator {
 	iter := &iter{
 		singleIter: singleIter{
 			listParams: p,
+			endpoint:   e,
 			query:      query,
 			lazyLoad:   lazyLoad,
 		},
@@ -390,7 +533,7 @@ func newIterator(p ListParamsContainer, query queryFunc, lazyLoad bool) Iterator
 	// ensure default pagination values are defined.
 	p.setDefaultPagination(defaultPage, defaultLimit)
 
-	if !lazyLoad {
+	if iter.resume() && !lazyLoad {
 		iter.getPage()
 	}
 
@@ -404,14 +547,15 @@ func newIterator(p ListParamsContainer, query queryFunc, lazyLoad bool) Iterator
 //
 // lazyLoad determines if the initial page of results is loaded when the iterator is generated
 // or when we call our initial call to Next.
-func newSimulatedIterator(p ListParamsContainer, query queryFunc, lazyLoad bool) Iterator {
+func newSimulatedIterator(p ListParamsContainer, e endpoint, query queryFunc, lazyLoad bool) Iterator {
 	iter := &singleIter{
 		query:      query,
 		listParams: p,
+		endpoint:   e,
 		lazyLoad:   lazyLoad,
 	}
 
-	if !lazyLoad {
+	if iter.resume() && !lazyLoad {
 		iter.getPage()
 	}
 
@@ -0,0 +1,261 @@
+package trakt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// pagedQuery serves the entries 1 to n over pages of the supplied size, recording the pages
+// which were requested and failing the request for the page fail.
+type pagedQuery struct {
+	mu    sync.Mutex
+	n     int64
+	size  int64
+	fail  int64
+	pages []int64
+}
+
+func (q *pagedQuery) query(p ListParamsContainer) (iterationFrame, error) {
+	page, _ := p.pagination()
+
+	q.mu.Lock()
+	q.pages = append(q.pages, page)
+	fail := q.fail
+	q.mu.Unlock()
+
+	if page == fail {
+		return nil, &Error{Code: ErrorCodeServerError}
+	}
+
+	rcv := make([]*json.RawMessage, 0)
+	for i := (page-1)*q.size + 1; i <= page*q.size && i <= q.n; i++ {
+		raw := json.RawMessage(strconv.FormatInt(i, 10))
+		rcv = append(rcv, &raw)
+	}
+
+	return &frame{r: &rcv, listMeta: &listMeta{
+		currentPage: page,
+		limit:       q.size,
+		totalPages:  (q.n + q.size - 1) / q.size,
+		totalCount:  q.n,
+	}}, nil
+}
+
+// reset clears the pages which were requested and the page which fails.
+func (q *pagedQuery) reset() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pages, q.fail = nil, 0
+}
+
+// requested returns the pages which were requested.
+func (q *pagedQuery) requested() []int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]int64(nil), q.pages...)
+}
+
+// iterator returns an iterator over the entries.
+func (q *pagedQuery) iterator(lazy bool) Iterator {
+	p := &BasicListParams{Limit: Int64(q.size)}
+	return newIterator(p, endpoint{method: "GET", path: "/entries"}, q.query, lazy)
+}
+
+// collect returns the remaining entries of the iterator.
+func collect(t *testing.T, it Iterator) []int {
+	t.Helper()
+
+	entries, err := NewIter[int](it).Collect()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return entries
+}
+
+func TestIterator_SeekPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		lazy    bool
+		page    int64
+		seeked  []int64
+		entries []int
+	}{
+		{name: "lazy", lazy: true, page: 2, entries: []int{4, 5, 6, 7}},
+		{name: "eager", page: 2, seeked: []int64{2}, entries: []int{4, 5, 6, 7}},
+		{name: "last page", page: 3, seeked: []int64{3}, entries: []int{7}},
+		{name: "before first page", page: 0, seeked: []int64{1}, entries: []int{1, 2, 3, 4, 5, 6, 7}},
+		{name: "after last page", page: 4, seeked: []int64{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &pagedQuery{n: 7, size: 3}
+			it := q.iterator(tt.lazy)
+			it.Next()
+
+			q.reset()
+			it.SeekPage(tt.page)
+
+			// lazy iterators only retrieve the page once Next is called.
+			if pages := q.requested(); !reflect.DeepEqual(pages, tt.seeked) {
+				t.Errorf("expected pages %v to be requested, got %v", tt.seeked, pages)
+			}
+
+			if entries := collect(t, it); !reflect.DeepEqual(entries, tt.entries) {
+				t.Errorf("expected %v, got %v", tt.entries, entries)
+			}
+		})
+	}
+}
+
+func TestIterator_SeekPageAfterError(t *testing.T) {
+	for _, lazy := range []bool{true, false} {
+		q := &pagedQuery{n: 7, size: 3, fail: 2}
+		it := q.iterator(lazy)
+		for it.Next() {
+		}
+
+		if it.Err() == nil {
+			t.Fatal("expected an error retrieving the second page")
+		}
+
+		q.reset()
+		it.SeekPage(2)
+		if err := it.Err(); err != nil {
+			t.Errorf("expected the error to be cleared, got %v", err)
+		}
+
+		if want, entries := []int{4, 5, 6, 7}, collect(t, it); !reflect.DeepEqual(entries, want) {
+			t.Errorf("expected %v, got %v", want, entries)
+		}
+	}
+}
+
+// page returns the pagination of the page of the pagedQuery which serves the supplied entry.
+func (q *pagedQuery) page(entry int) Pagination {
+	return Pagination{
+		CurrentPage: (int64(entry)-1)/q.size + 1,
+		Limit:       q.size,
+		TotalPages:  (q.n + q.size - 1) / q.size,
+		TotalCount:  q.n,
+	}
+}
+
+func TestIterator_Pagination(t *testing.T) {
+	tests := []struct {
+		name     string
+		lazy     bool
+		prefetch int
+		seek     int64
+		// first the pagination expected before the first call to Next.
+		first   Pagination
+		entries []int
+	}{
+		{name: "lazy", lazy: true, entries: []int{1, 2, 3, 4, 5, 6, 7}},
+		{name: "eager", first: Pagination{CurrentPage: 1, Limit: 3, TotalPages: 3, TotalCount: 7}, entries: []int{1, 2, 3, 4, 5, 6, 7}},
+		{name: "prefetch", lazy: true, prefetch: 2, entries: []int{1, 2, 3, 4, 5, 6, 7}},
+		{name: "lazy seek", lazy: true, seek: 3, entries: []int{7}},
+		{name: "eager seek", seek: 3, first: Pagination{CurrentPage: 3, Limit: 3, TotalPages: 3, TotalCount: 7}, entries: []int{7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &pagedQuery{n: 7, size: 3}
+			it := q.iterator(tt.lazy)
+			it.Prefetch(tt.prefetch)
+
+			if tt.seek > 0 {
+				// move past the first page, so that seeking has to replace its pagination.
+				for i := 0; i < 4 && it.Next(); i++ {
+				}
+
+				it.SeekPage(tt.seek)
+			}
+
+			if p := it.Pagination(); p != tt.first {
+				t.Errorf("expected %+v before the first page, got %+v", tt.first, p)
+			}
+
+			var entries []int
+			for it.Next() {
+				var v int
+				if err := it.Scan(&v); err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if want, p := q.page(v), it.Pagination(); p != want {
+					t.Errorf("expected %+v for entry %d, got %+v", want, v, p)
+				}
+
+				entries = append(entries, v)
+			}
+
+			if err := it.Err(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if !reflect.DeepEqual(entries, tt.entries) {
+				t.Errorf("expected %v, got %v", tt.entries, entries)
+			}
+		})
+	}
+}
+
+func TestIterator_PaginationSimulated(t *testing.T) {
+	q := &pagedQuery{n: 3, size: 3}
+	it := NewIter[int](newSimulatedIterator(&BasicListParams{Page: Int64(1), Limit: Int64(3)}, endpoint{method: "GET", path: "/entries"}, q.query, false))
+
+	for it.Next() {
+		if p := it.Pagination(); p != (Pagination{}) {
+			t.Errorf("expected an empty pagination, got %+v", p)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestIterator_PaginationHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		w.Header().Set("X-Pagination-Page", page)
+		w.Header().Set("X-Pagination-Limit", "2")
+		w.Header().Set("X-Pagination-Page-Count", "2")
+		w.Header().Set("X-Pagination-Item-Count", "3")
+
+		if page == "1" {
+			_, _ = w.Write([]byte(`[1,2]`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`[3]`))
+	}))
+	defer srv.Close()
+
+	c := NewWithBackend("key", NewBackend(&BackendConfig{URL: srv.URL, GetRateLimit: NoRateLimit}))
+	it := NewIter[int](c.NewIterator(http.MethodGet, "/entries", &BasicListParams{Limit: Int64(2)}))
+
+	pages := map[int]int64{1: 1, 2: 1, 3: 2}
+	for it.Next() {
+		v, err := it.Current()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		want := Pagination{CurrentPage: pages[v], Limit: 2, TotalPages: 2, TotalCount: 3}
+		if p := it.Pagination(); p != want {
+			t.Errorf("expected %+v for entry %d, got %+v", want, v, p)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
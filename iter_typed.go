@@ -0,0 +1,112 @@
+package trakt
+
+// Iter is a typed iterator which wraps an iterator, decoding each entry into T
+// rather than requiring a receiver to be passed to Scan:
+//
+//  it := show.Trending(&trakt.ListParams{}).Iter()
+//  for it.Next() {
+//  	s, err := it.Current()
+//  	if err != nil {
+//  		return err
+//  	}
+//
+//  	fmt.Println(s.Title, s.Watchers)
+//  }
+//
+//  if err := it.Err(); err != nil {
+//  	return err
+//  }
+//
+// Every iterator returned by a client function has an Iter function which returns its
+// typed variant, NewIter can be used to wrap any other iterator.
+//
+// Like the iterators it wraps, Iter is thread-safe.
+type Iter[T any] struct {
+	it   BasicIterator
+	scan func() (T, error)
+
+	// next and err replace moving to the next entry and retrieving the error of the
+	// wrapped iterator, they are defined on iterators derived using Filter, Take or Dedup.
+	next func() bool
+	err  func() error
+}
+
+// NewIter returns a typed iterator which decodes each entry in the supplied
+// iterator into T, T is usually a pointer to the entry type, i.e *Show.
+func NewIter[T any](it BasicIterator) *Iter[T] {
+	return newIter(it, func() (T, error) {
+		var rcv T
+		err := it.Scan(&rcv)
+		return rcv, err
+	})
+}
+
+// NewIterFunc returns a typed iterator which uses scan to decode the current entry in the
+// supplied iterator, this allows the entry to be validated or decoded differently.
+func NewIterFunc[T any](it BasicIterator, scan func() (T, error)) *Iter[T] { return newIter(it, scan) }
+
+// newIter returns a typed iterator which uses scan to decode the current entry.
+func newIter[T any](it BasicIterator, scan func() (T, error)) *Iter[T] {
+	return &Iter[T]{it: it, scan: scan}
+}
+
+// Next moves the cursor to the next entry in the results, retrieving the
+// next page from the API if required. false is returned once there are
+// no entries left or an error occurred.
+func (i *Iter[T]) Next() bool {
+	if i.next != nil {
+		return i.next()
+	}
+
+	return i.it.Next()
+}
+
+// Current decodes the current entry.
+func (i *Iter[T]) Current() (T, error) { return i.scan() }
+
+// Err returns the error which occurred retrieving a page, if any.
+func (i *Iter[T]) Err() error {
+	if i.err != nil {
+		return i.err()
+	}
+
+	return i.it.Err()
+}
+
+// Cursor returns the position of the current entry, which can be saved and used to
+// resume iterating from the entry after it by setting it on the list params.
+func (i *Iter[T]) Cursor() *Cursor { return i.it.Cursor() }
+
+// PageLimit sets an absolute limit on how many pages to iterate through. This
+// has no effect on iterators which do not paginate.
+func (i *Iter[T]) PageLimit(page int64) {
+	if it, ok := i.it.(Iterator); ok {
+		it.PageLimit(page)
+	}
+}
+
+// Prefetch enables fetching up to n pages ahead of the current page concurrently. This
+// has no effect on iterators which do not paginate.
+func (i *Iter[T]) Prefetch(n int) {
+	if it, ok := i.it.(Iterator); ok {
+		it.Prefetch(n)
+	}
+}
+
+// Pagination returns the pagination information for the current page. It is empty
+// for iterators which do not paginate.
+func (i *Iter[T]) Pagination() Pagination {
+	if it, ok := i.it.(Iterator); ok {
+		return it.Pagination()
+	}
+
+	return Pagination{}
+}
+
+// SeekPage moves the iterator to the start of the supplied page without retrieving the
+// pages before it. This has no effect on iterators which do not paginate.
+func (i *Iter[T]) SeekPage(page int64) {
+	if it, ok := i.it.(Iterator); ok {
+		it.SeekPage(page)
+	}
+}
@@ -11,3 +11,6 @@ func (c *LanguageIterator) Language() (*Language, error) {
 	rcv := &Language{}
 	return rcv, c.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (c *LanguageIterator) Iter() *Iter[*Language] { return newIter(c, c.Language) }
@@ -7,8 +7,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client the language client.
-type client struct{ b trakt.BaseClient }
+// Client the language client.
+type Client struct{ b trakt.PathClient }
 
 // List retrieves a list of all languages, including names and codes.
 func List(params *trakt.ListByTypeParams) *trakt.LanguageIterator {
@@ -16,10 +16,10 @@ func List(params *trakt.ListByTypeParams) *trakt.LanguageIterator {
 }
 
 // List retrieves a list of all languages, including names and codes.
-func (c *client) List(params *trakt.ListByTypeParams) *trakt.LanguageIterator {
-	path := trakt.FormatURLPath("/languages/%s", params.Type)
+func (c *Client) List(params *trakt.ListByTypeParams) *trakt.LanguageIterator {
+	path := trakt.NewPath("/languages/%s", params.Type)
 	return &trakt.LanguageIterator{
-		BasicIterator: c.b.NewSimulatedIteratorWithCondition(http.MethodGet, path, params, func() error {
+		BasicIterator: c.b.NewSimulatedIteratorWithConditionPath(http.MethodGet, path, params, func() error {
 			switch params.Type {
 			case trakt.TypeMovie, trakt.TypeShow:
 				return nil
@@ -28,12 +28,16 @@ func (c *client) List(params *trakt.ListByTypeParams) *trakt.LanguageIterator {
 			return &trakt.Error{
 				HTTPStatusCode: http.StatusUnprocessableEntity,
 				Body:           "invalid type: only movie / show are applicable",
-				Resource:       path,
+				Resource:       path.Path,
 				Code:           trakt.ErrorCodeValidationError,
 			}
 		}),
 	}
 }
 
+// NewClient initialises a new language client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC retrieves an instance of a language client.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -0,0 +1,221 @@
+package trakt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// retryAfterHeader the header trakt uses to inform us how many seconds to wait
+	// before performing another request once a rate limit has been exceeded.
+	retryAfterHeader = "Retry-After"
+	// rateLimitHeader the header which contains the JSON encoded details of the
+	// rate limit which has been exceeded.
+	rateLimitHeader = "X-Ratelimit"
+)
+
+var (
+	// DefaultGetRateLimit the default budget for GET requests, trakt allows
+	// 1000 calls every 5 minutes.
+	DefaultGetRateLimit = &RateLimit{Limit: 1000, Period: 5 * time.Minute}
+	// DefaultWriteRateLimit the default budget for POST, PUT and DELETE requests, trakt
+	// allows 1 call per second.
+	DefaultWriteRateLimit = &RateLimit{Limit: 1, Period: time.Second}
+	// NoRateLimit can be supplied to disable rate limiting for a set of requests.
+	NoRateLimit = &RateLimit{}
+)
+
+// RateLimit represents a budget of requests which can be performed over a period.
+type RateLimit struct {
+	// Limit the amount of requests which can be performed over the period.
+	// a limit less than or equal to zero disables the rate limit.
+	Limit int
+	// Period the window which the limit applies to.
+	Period time.Duration
+}
+
+// rateLimitDetails the JSON representation of the X-Ratelimit header
+// which is supplied by trakt when a rate limit has been exceeded.
+type rateLimitDetails struct {
+	Name      string    `json:"name"`
+	Period    int64     `json:"period"`
+	Limit     int64     `json:"limit"`
+	Remaining int64     `json:"remaining"`
+	Until     time.Time `json:"until"`
+}
+
+// bucket is a token bucket which is used to throttle a single
+// set of requests.
+type bucket struct {
+	mu sync.Mutex
+
+	// limit the maximum amount of tokens the bucket can hold.
+	limit float64
+	// interval the time it takes to regenerate a single token.
+	interval time.Duration
+	// tokens the amount of tokens currently available, this
+	// can go negative when a request has to wait for a token.
+	tokens float64
+	// last the last time the amount of tokens was updated.
+	last time.Time
+	// blockedUntil the time trakt has told us not to perform any
+	// more requests until.
+	blockedUntil time.Time
+}
+
+// newBucket initialises a new bucket from the supplied rate limit
+// a nil bucket is returned if the rate limit is disabled.
+func newBucket(r *RateLimit) *bucket {
+	if r == nil || r.Limit <= 0 || r.Period <= 0 {
+		return nil
+	}
+
+	return &bucket{
+		limit:    float64(r.Limit),
+		interval: r.Period / time.Duration(r.Limit),
+		tokens:   float64(r.Limit),
+		last:     time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long we have to wait
+// before the token can be used.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// regenerate any tokens since the last time the bucket was updated.
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += float64(elapsed) / float64(b.interval)
+		if b.tokens > b.limit {
+			b.tokens = b.limit
+		}
+		b.last = now
+	}
+
+	b.tokens--
+
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens * float64(b.interval))
+	}
+
+	if blocked := b.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+
+	return wait
+}
+
+// cancel returns a reserved token to the bucket, this is used when a
+// request is cancelled while waiting for a token.
+func (b *bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+}
+
+// block stops any tokens being used until the supplied time.
+func (b *bucket) block(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// limiter throttles outgoing requests using separate budgets for
+// read and write requests.
+type limiter struct {
+	read  *bucket
+	write *bucket
+}
+
+// newLimiter initialises a new limiter using the supplied budgets.
+func newLimiter(read, write *RateLimit) *limiter {
+	return &limiter{read: newBucket(read), write: newBucket(write)}
+}
+
+// bucket retrieves the bucket which is used for the supplied HTTP method.
+func (l *limiter) bucket(method string) *bucket {
+	if isHTTPWriteMethod(method) {
+		return l.write
+	}
+
+	return l.read
+}
+
+// wait blocks until a request using the supplied method is allowed to be performed
+// or until the context is done, in which case the context error is returned.
+func (l *limiter) wait(ctx context.Context, method string) error {
+	b := l.bucket(method)
+	if b == nil {
+		return nil
+	}
+
+	d := b.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// block stops any requests using the supplied method from being performed
+// for the supplied duration. false is returned if requests using the method
+// are not rate limited, in which case nothing is blocked.
+func (l *limiter) block(method string, d time.Duration) bool {
+	b := l.bucket(method)
+	if b == nil {
+		return false
+	}
+
+	b.block(time.Now().Add(d))
+	return true
+}
+
+// retryAfter determines how long trakt has asked us to wait before performing
+// another request. This uses both the Retry-After and X-Ratelimit headers, using
+// whichever is the longest.
+func retryAfter(h http.Header) time.Duration {
+	if h == nil {
+		return 0
+	}
+
+	var d time.Duration
+	if v := h.Get(retryAfterHeader); v != "" {
+		if s, err := strconv.ParseInt(v, 10, 64); err == nil {
+			d = time.Duration(s) * time.Second
+		} else if t, err := http.ParseTime(v); err == nil {
+			d = time.Until(t)
+		}
+	}
+
+	if v := h.Get(rateLimitHeader); v != "" {
+		var details rateLimitDetails
+		if err := json.Unmarshal([]byte(v), &details); err == nil && !details.Until.IsZero() {
+			if until := time.Until(details.Until); until > d {
+				d = until
+			}
+		}
+	}
+
+	if d < 0 {
+		return 0
+	}
+
+	return d
+}
@@ -53,6 +53,9 @@ func (l *ListIterator) List() (*List, error) {
 	return rcv, l.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (l *ListIterator) Iter() *Iter[*List] { return newIter(l, l.List) }
+
 // RecentList represents a list with the most
 // recent like and comment figures, usually over the last
 // 7 days. The like and comment counts on the list are for
@@ -69,3 +72,6 @@ func (r *RecentListIterator) RecentList() (*RecentList, error) {
 	rcv := &RecentList{}
 	return rcv, r.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (r *RecentListIterator) Iter() *Iter[*RecentList] { return newIter(r, r.RecentList) }
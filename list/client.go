@@ -8,8 +8,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a list client.
-type client struct{ b trakt.BaseClient }
+// Client represents a list client.
+type Client struct{ b trakt.PathClient }
 
 // Trending returns all lists with the most likes and comments over the last 7 days.
 //
@@ -21,7 +21,7 @@ func Trending(params *trakt.BasicListParams) *trakt.RecentListIterator {
 // Trending returns all lists with the most likes and comments over the last 7 days.
 //
 //  - Pagination
-func (c *client) Trending(params *trakt.BasicListParams) *trakt.RecentListIterator {
+func (c *Client) Trending(params *trakt.BasicListParams) *trakt.RecentListIterator {
 	return c.generateListIterator("trending", params)
 }
 
@@ -37,15 +37,19 @@ func Popular(params *trakt.BasicListParams) *trakt.RecentListIterator {
 // likes and comments.
 //
 //  - Pagination
-func (c *client) Popular(params *trakt.BasicListParams) *trakt.RecentListIterator {
+func (c *Client) Popular(params *trakt.BasicListParams) *trakt.RecentListIterator {
 	return c.generateListIterator("popular", params)
 }
 
 // generateListIterator generates an iterator which retrieves a set of lists by action.
-func (c *client) generateListIterator(action string, params *trakt.BasicListParams) *trakt.RecentListIterator {
-	path := trakt.FormatURLPath("/lists/%s", action)
-	return &trakt.RecentListIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) generateListIterator(action string, params *trakt.BasicListParams) *trakt.RecentListIterator {
+	path := trakt.NewPath("/lists/%s", action)
+	return &trakt.RecentListIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
+// NewClient initialises a new list client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new list client with the current backend configuration.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strings"
 )
 
 //
@@ -138,3 +140,186 @@ type LeveledLoggerInterface interface {
 	// Warnf logs a warning message using Printf conventions.
 	Warnf(format string, v ...interface{})
 }
+
+// Field is a key / value pair which is attached to a structured log message.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F generates a new Field.
+func F(key string, value interface{}) Field { return Field{Key: key, Value: value} }
+
+// The keys of the fields which are attached to the messages logged by the backend.
+const (
+	FieldMethod      = "method"
+	FieldPath        = "path"
+	FieldStatus      = "status"
+	FieldDuration    = "duration"
+	FieldRetry       = "retry"
+	FieldRequestID   = "request_id"
+	FieldError       = "error"
+	FieldBody        = "body"
+	FieldRetryAfter  = "retry_after"
+	FieldCircuitFrom = "circuit_from"
+	FieldCircuitTo   = "circuit_to"
+)
+
+// StructuredLoggerInterface provides a leveled logging interface where each message
+// is accompanied by a set of key / value fields rather than being formatted using
+// Printf conventions.
+//
+// Every message and field is passed through the redactor before it reaches the
+// logger, so access tokens, refresh tokens and client secrets are never logged.
+type StructuredLoggerInterface interface {
+	// Debug logs a debug message with the supplied fields.
+	Debug(msg string, fields ...Field)
+
+	// Error logs an error message with the supplied fields.
+	Error(msg string, fields ...Field)
+
+	// Info logs an informational message with the supplied fields.
+	Info(msg string, fields ...Field)
+
+	// Warn logs a warning message with the supplied fields.
+	Warn(msg string, fields ...Field)
+}
+
+// NewStructuredLogger wraps a LeveledLoggerInterface so it can be used where a structured
+// logger is required, the fields are appended to the message in key=value form.
+func NewStructuredLogger(l LeveledLoggerInterface) StructuredLoggerInterface {
+	return &leveledStructuredLogger{l}
+}
+
+// leveledStructuredLogger adapts a LeveledLoggerInterface to a StructuredLoggerInterface.
+type leveledStructuredLogger struct{ l LeveledLoggerInterface }
+
+// Debug implements StructuredLoggerInterface interface.
+func (l *leveledStructuredLogger) Debug(msg string, fields ...Field) {
+	l.l.Debugf("%s", formatFields(msg, fields))
+}
+
+// Error implements StructuredLoggerInterface interface.
+func (l *leveledStructuredLogger) Error(msg string, fields ...Field) {
+	l.l.Errorf("%s", formatFields(msg, fields))
+}
+
+// Info implements StructuredLoggerInterface interface.
+func (l *leveledStructuredLogger) Info(msg string, fields ...Field) {
+	l.l.Infof("%s", formatFields(msg, fields))
+}
+
+// Warn implements StructuredLoggerInterface interface.
+func (l *leveledStructuredLogger) Warn(msg string, fields ...Field) {
+	l.l.Warnf("%s", formatFields(msg, fields))
+}
+
+// formatFields appends the fields to the message in key=value form.
+func formatFields(msg string, fields []Field) string {
+	var sb strings.Builder
+	sb.WriteString(msg)
+	for _, f := range fields {
+		fmt.Fprintf(&sb, " %s=%v", f.Key, f.Value)
+	}
+
+	return sb.String()
+}
+
+// redactedValue the value which replaces any sensitive value.
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeys the keys of the values which are redacted, these are matched against
+// both field keys and the keys in any JSON or form encoded values being logged.
+var sensitiveKeys = []string{"access_token", "refresh_token", "client_secret", "authorization"}
+
+// sensitiveKeyPattern matches any of the sensitive keys.
+var sensitiveKeyPattern = strings.Join(sensitiveKeys, "|")
+
+// sensitivePatterns match the sensitive values in JSON bodies, form / query encoded
+// values and bearer authorization headers, along with their replacements.
+//
+// JSON bodies are also matched once escaped, as the body of an *Error is escaped when
+// the error is serialized into a string.
+var sensitivePatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`(?i)("(?:` + sensitiveKeyPattern + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`), `${1}"` + redactedValue + `"`},
+	{regexp.MustCompile(`(?i)(\\"(?:` + sensitiveKeyPattern + `)\\"\s*:\s*)\\"[^"\\]*\\"`), `${1}\"` + redactedValue + `\"`},
+	{regexp.MustCompile(`(?i)\b((?:` + sensitiveKeyPattern + `)=)[^&\s"]*`), `${1}` + redactedValue},
+	{regexp.MustCompile(`(?i)(bearer\s+)[^\s",]+`), `${1}` + redactedValue},
+}
+
+// Redact removes any access tokens, refresh tokens and client secrets from s.
+func Redact(s string) string {
+	for _, p := range sensitivePatterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+
+	return s
+}
+
+// redactingLogger redacts every message and field before passing it to the wrapped logger.
+type redactingLogger struct{ l StructuredLoggerInterface }
+
+// Debug implements StructuredLoggerInterface interface.
+func (l *redactingLogger) Debug(msg string, fields ...Field) {
+	l.l.Debug(Redact(msg), redactFields(fields)...)
+}
+
+// Error implements StructuredLoggerInterface interface.
+func (l *redactingLogger) Error(msg string, fields ...Field) {
+	l.l.Error(Redact(msg), redactFields(fields)...)
+}
+
+// Info implements StructuredLoggerInterface interface.
+func (l *redactingLogger) Info(msg string, fields ...Field) {
+	l.l.Info(Redact(msg), redactFields(fields)...)
+}
+
+// Warn implements StructuredLoggerInterface interface.
+func (l *redactingLogger) Warn(msg string, fields ...Field) {
+	l.l.Warn(Redact(msg), redactFields(fields)...)
+}
+
+// redactFields redacts the values of the fields, fields whose key is sensitive have
+// their value replaced entirely.
+func redactFields(fields []Field) []Field {
+	out := make([]Field, len(fields))
+	for i, f := range fields {
+		out[i] = Field{Key: f.Key, Value: redactValue(f.Key, f.Value)}
+	}
+
+	return out
+}
+
+// redactValue redacts a single field value.
+func redactValue(key string, v interface{}) interface{} {
+	for _, k := range sensitiveKeys {
+		if strings.EqualFold(key, k) {
+			return redactedValue
+		}
+	}
+
+	switch t := v.(type) {
+	case string:
+		return Redact(t)
+	case []byte:
+		return Redact(string(t))
+	case error:
+		return Redact(t.Error())
+	}
+
+	return v
+}
+
+// newBackendLogger generates the logger used by a backend, preferring the structured
+// logger if one is configured. The logger always redacts sensitive values.
+func newBackendLogger(config *BackendConfig) StructuredLoggerInterface {
+	l := config.Logger
+	if l == nil {
+		l = NewStructuredLogger(config.LeveledLogger)
+	}
+
+	return &redactingLogger{l}
+}
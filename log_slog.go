@@ -0,0 +1,71 @@
+//go:build go1.21
+// +build go1.21
+
+package trakt
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogLogger generates a StructuredLoggerInterface which logs to the supplied slog.Logger,
+// each field is logged as an attribute. If l is nil, slog.Default is used.
+func NewSlogLogger(l *slog.Logger) StructuredLoggerInterface {
+	if l == nil {
+		l = slog.Default()
+	}
+
+	return &slogLogger{l}
+}
+
+// NewSlogHandlerLogger generates a StructuredLoggerInterface which logs to the supplied slog.Handler.
+func NewSlogHandlerLogger(h slog.Handler) StructuredLoggerInterface {
+	return NewSlogLogger(slog.New(h))
+}
+
+// SlogLevel converts a Level into the equivalent slog.Level. LevelNone is converted
+// to a level above slog.LevelError so that nothing is logged.
+func SlogLevel(l Level) slog.Level {
+	switch l {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	}
+
+	return slog.LevelError + 4
+}
+
+// slogLogger adapts a slog.Logger to a StructuredLoggerInterface.
+type slogLogger struct{ l *slog.Logger }
+
+// Debug implements StructuredLoggerInterface interface.
+func (l *slogLogger) Debug(msg string, fields ...Field) { l.log(slog.LevelDebug, msg, fields) }
+
+// Error implements StructuredLoggerInterface interface.
+func (l *slogLogger) Error(msg string, fields ...Field) { l.log(slog.LevelError, msg, fields) }
+
+// Info implements StructuredLoggerInterface interface.
+func (l *slogLogger) Info(msg string, fields ...Field) { l.log(slog.LevelInfo, msg, fields) }
+
+// Warn implements StructuredLoggerInterface interface.
+func (l *slogLogger) Warn(msg string, fields ...Field) { l.log(slog.LevelWarn, msg, fields) }
+
+// log logs the message and fields at the supplied level.
+func (l *slogLogger) log(level slog.Level, msg string, fields []Field) {
+	ctx := context.Background()
+	if !l.l.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := make([]slog.Attr, len(fields))
+	for i, f := range fields {
+		attrs[i] = slog.Any(f.Key, f.Value)
+	}
+
+	l.l.LogAttrs(ctx, level, msg, attrs...)
+}
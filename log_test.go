@@ -0,0 +1,59 @@
+package trakt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "json",
+			in:   `{"access_token":"abc","scope":"public"}`,
+			want: `{"access_token":"[REDACTED]","scope":"public"}`,
+		},
+		{
+			name: "escaped json",
+			in:   `{"body":"{\"access_token\":\"abc\",\"refresh_token\": \"def\"}"}`,
+			want: `{"body":"{\"access_token\":\"[REDACTED]\",\"refresh_token\": \"[REDACTED]\"}"}`,
+		},
+		{
+			name: "form",
+			in:   `client_id=id&client_secret=secret`,
+			want: `client_id=id&client_secret=[REDACTED]`,
+		},
+		{
+			name: "bearer",
+			in:   `Authorization: Bearer abc`,
+			want: `Authorization: Bearer [REDACTED]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.in); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRedact_Error(t *testing.T) {
+	err := &Error{
+		HTTPStatusCode: http.StatusUnauthorized,
+		Resource:       "/oauth/token",
+		Body:           `{"access_token":"abc","refresh_token":"def","client_secret":"ghi"}`,
+	}
+
+	got := redactValue(FieldError, error(err)).(string)
+	for _, secret := range []string{"abc", "def", "ghi"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("expected %q to be redacted, got %s", secret, got)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package trakt
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// Request represents a single call to the trakt API as seen by a Middleware.
+type Request struct {
+	// HTTPRequest the outgoing HTTP request. Middleware can modify this
+	// request, i.e to inject additional headers.
+	HTTPRequest *http.Request
+	// Params the parameters which the request was generated from.
+	Params ParamsContainer
+	// Body the JSON encoded body which is sent with write requests.
+	Body []byte
+	// PathTemplate the template the path was formatted from, i.e /shows/%s/seasons.
+	PathTemplate string
+	// DryRun whether dry-run is enabled for the request, either on the params or on the
+	// backend configuration. A write request with dry-run enabled is never sent.
+	DryRun bool
+	// Response the HTTP response for the request, this is only available
+	// once the request has been performed and is nil if the request failed
+	// to reach trakt or was served without making a request.
+	Response *http.Response
+}
+
+// Handler performs a request to the trakt API and decodes the response into v.
+// Any error returned from the API is returned as a *Error.
+type Handler func(r *Request, v interface{}) error
+
+// Middleware wraps a Handler to provide additional functionality, i.e tracing,
+// auditing or fault injection. A middleware can inspect or modify the request
+// before calling the next handler and inspect or rewrite the decoded response
+// or error once the next handler returns. A middleware can also short-circuit
+// the request by not calling the next handler at all.
+type Middleware func(next Handler) Handler
+
+// chain wraps the supplied handler with the middleware, the first middleware
+// in the chain is the outermost and sees the request first.
+func chain(h Handler, middleware []Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+
+	return h
+}
+
+// handle is the innermost handler which actually performs the HTTP request.
+// con represents the container for the receiver which is populated from the
+// response headers.
+func (s *backendImplementation) handle(con interface{}) Handler {
+	return func(r *Request, v interface{}) error {
+		if isHTTPWriteMethod(r.HTTPRequest.Method) && r.DryRun {
+			s.logger.Info("Dry-run, request not sent", requestFields(r.HTTPRequest)...)
+			return newDryRunError(r.HTTPRequest, r.Body)
+		}
+
+		res, err := s.do(newRequestInfo(r), r.HTTPRequest, bytes.NewBuffer(r.Body), r.Params, v, con)
+		r.Response = res
+		return err
+	}
+}
@@ -0,0 +1,240 @@
+package trakt_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/sync"
+	"github.com/jacklaaa89/trakt/trakttest"
+)
+
+// movieTitle decodes only the title of a movie.
+type movieTitle struct {
+	Title string `json:"title"`
+}
+
+// newMiddlewareClient generates a client for the server which passes every request through the middleware.
+func newMiddlewareClient(srv *trakttest.Server, middleware ...trakt.Middleware) *trakt.Client {
+	config := srv.BackendConfig()
+	config.Middleware = middleware
+	return trakt.New("client-id", config)
+}
+
+func TestMiddleware_Order(t *testing.T) {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	m := srv.AddMovie(&trakttest.Movie{Title: "Tron", Year: 1982})
+
+	var calls []string
+	record := func(name string) trakt.Middleware {
+		return func(next trakt.Handler) trakt.Handler {
+			return func(r *trakt.Request, v interface{}) error {
+				calls = append(calls, name+" request")
+				err := next(r, v)
+				calls = append(calls, name+" response")
+				return err
+			}
+		}
+	}
+
+	c := newMiddlewareClient(srv, record("first"), record("second"), record("third"))
+	rcv := &movieTitle{}
+	if err := c.Call(http.MethodGet, "/movies/"+string(m.IDs.Slug), &trakt.BasicParams{}, rcv); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{
+		"first request", "second request", "third request",
+		"third response", "second response", "first response",
+	}
+
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected %v, got %v", want, calls)
+	}
+}
+
+func TestMiddleware_ShortCircuit(t *testing.T) {
+	srv := trakttest.NewServer()
+	srv.Close()
+
+	var reached bool
+	c := newMiddlewareClient(srv,
+		func(next trakt.Handler) trakt.Handler {
+			return func(r *trakt.Request, v interface{}) error {
+				v.(*movieTitle).Title = "Cached"
+				return nil
+			}
+		},
+		func(next trakt.Handler) trakt.Handler {
+			return func(r *trakt.Request, v interface{}) error {
+				reached = true
+				return next(r, v)
+			}
+		},
+	)
+
+	// the server has been closed, so the request only succeeds if it is never sent.
+	rcv := &movieTitle{}
+	if err := c.Call(http.MethodGet, "/movies/tron-1982", &trakt.BasicParams{}, rcv); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if rcv.Title != "Cached" {
+		t.Errorf("expected %q, got %q", "Cached", rcv.Title)
+	}
+
+	if reached {
+		t.Error("expected the middleware after the short-circuit not to be called")
+	}
+}
+
+func TestMiddleware_ModifyRequest(t *testing.T) {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	token := srv.Authorize("sean")
+	m := srv.AddMovie(&trakttest.Movie{Title: "Tron", Year: 1982})
+
+	tests := []struct {
+		name   string
+		path   string
+		params trakt.ParamsContainer
+		modify func(r *http.Request)
+	}{
+		{
+			name:   "header",
+			path:   "/sync/last_activities",
+			params: &trakt.Params{},
+			modify: func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) },
+		},
+		{
+			name:   "path",
+			path:   "/movies/unknown",
+			params: &trakt.BasicParams{},
+			modify: func(r *http.Request) { r.URL.Path = "/movies/" + string(m.IDs.Slug) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newMiddlewareClient(srv, func(next trakt.Handler) trakt.Handler {
+				return func(r *trakt.Request, v interface{}) error {
+					tt.modify(r.HTTPRequest)
+					return next(r, v)
+				}
+			})
+
+			// without the modification the request fails as unauthorized or not found.
+			var rcv map[string]interface{}
+			if err := c.Call(http.MethodGet, tt.path, tt.params, &rcv); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMiddleware_Response(t *testing.T) {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	m := srv.AddMovie(&trakttest.Movie{Title: "Tron", Year: 1982})
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		err    error
+	}{
+		{name: "success", path: "/movies/" + string(m.IDs.Slug), status: http.StatusOK},
+		{name: "error", path: "/movies/unknown", status: http.StatusNotFound, err: trakt.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status int
+			var seen error
+			c := newMiddlewareClient(srv, func(next trakt.Handler) trakt.Handler {
+				return func(r *trakt.Request, v interface{}) error {
+					err := next(r, v)
+					if r.Response != nil {
+						status = r.Response.StatusCode
+					}
+
+					// rewrite the error, so that a missing movie is not an error.
+					if seen = err; errors.Is(err, trakt.ErrNotFound) {
+						v.(*movieTitle).Title = "Missing"
+						return nil
+					}
+
+					return err
+				}
+			})
+
+			rcv := &movieTitle{}
+			if err := c.Call(http.MethodGet, tt.path, &trakt.BasicParams{}, rcv); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if status != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, status)
+			}
+
+			if !errors.Is(seen, tt.err) || (tt.err == nil && seen != nil) {
+				t.Errorf("expected %v, got %v", tt.err, seen)
+			}
+
+			if tt.err == nil && rcv.Title != "Tron" {
+				t.Errorf("expected %q, got %q", "Tron", rcv.Title)
+			}
+
+			if tt.err != nil && rcv.Title != "Missing" {
+				t.Errorf("expected the rewritten response, got %q", rcv.Title)
+			}
+		})
+	}
+}
+
+func TestMiddleware_DryRun(t *testing.T) {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	token := srv.Authorize("sean")
+	m := srv.AddMovie(&trakttest.Movie{Title: "Tron", Year: 1982})
+
+	var req *trakt.Request
+	var seen error
+	c := newMiddlewareClient(srv, func(next trakt.Handler) trakt.Handler {
+		return func(r *trakt.Request, v interface{}) error {
+			req = r
+			seen = next(r, v)
+			return seen
+		}
+	})
+
+	_, err := sync.NewClient(c).AddToHistory(&trakt.AddToHistoryParams{
+		Params: trakt.Params{OAuth: token, DryRun: true},
+		Movies: []*trakt.MediaHistoryParams{{IDs: m.IDs}},
+	})
+
+	if !errors.Is(err, trakt.ErrDryRun) || !errors.Is(seen, trakt.ErrDryRun) {
+		t.Fatalf("expected %v, got %v", trakt.ErrDryRun, err)
+	}
+
+	if !req.DryRun || req.Response != nil || len(req.Body) == 0 {
+		t.Errorf("expected a dry-run request with a body and no response, got %+v", req)
+	}
+
+	// the write was never sent, so the history is empty.
+	titles, _, err := iterateHistory(srv, &trakt.ListHistoryParams{ListParams: trakt.ListParams{OAuth: token}}, -1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(titles) != 0 {
+		t.Errorf("expected an empty history, got %v", titles)
+	}
+}
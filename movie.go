@@ -89,6 +89,9 @@ func (m *MovieIterator) Movie() (*Movie, error) {
 	return rcv, m.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (m *MovieIterator) Iter() *Iter[*Movie] { return newIter(m, m.Movie) }
+
 type Release struct {
 	Country       string      `json:"country"`
 	Certification string      `json:"certification"`
@@ -125,6 +128,9 @@ func (r *ReleaseIterator) Release() (*Release, error) {
 	return rcv, r.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (r *ReleaseIterator) Iter() *Iter[*Release] { return newIter(r, r.Release) }
+
 type TrendingMovie struct {
 	Movie    `json:"movie"`
 	Watchers int64 `json:"watchers"`
@@ -137,6 +143,9 @@ func (t *TrendingMovieIterator) Trending() (*TrendingMovie, error) {
 	return rcv, t.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (t *TrendingMovieIterator) Iter() *Iter[*TrendingMovie] { return newIter(t, t.Trending) }
+
 type RecentlyUpdatedMovie struct {
 	Movie     `json:"movie"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -149,6 +158,11 @@ func (m *RecentlyUpdatedMovieIterator) Movie() (*RecentlyUpdatedMovie, error) {
 	return rcv, m.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (m *RecentlyUpdatedMovieIterator) Iter() *Iter[*RecentlyUpdatedMovie] {
+	return newIter(m, m.Movie)
+}
+
 type MovieWithStatistics struct {
 	statistics
 	Movie `json:"movie"`
@@ -161,6 +175,9 @@ func (m *MovieWithStatisticsIterator) Movie() (*MovieWithStatistics, error) {
 	return rcv, m.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (m *MovieWithStatisticsIterator) Iter() *Iter[*MovieWithStatistics] { return newIter(m, m.Movie) }
+
 type AnticipatedMovie struct {
 	ListCount int64 `json:"list_count"`
 	Movie     `json:"movie"`
@@ -173,6 +190,9 @@ func (a *AnticipatedMovieIterator) Movie() (*AnticipatedMovie, error) {
 	return rcv, a.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (a *AnticipatedMovieIterator) Iter() *Iter[*AnticipatedMovie] { return newIter(a, a.Movie) }
+
 type BoxOfficeMovie struct {
 	Revenue int64 `json:"revenue"`
 	Movie   `json:"movie"`
@@ -184,3 +204,6 @@ func (m *BoxOfficeMovieIterator) Movie() (*BoxOfficeMovie, error) {
 	rcv := &BoxOfficeMovie{}
 	return rcv, m.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (m *BoxOfficeMovieIterator) Iter() *Iter[*BoxOfficeMovie] { return newIter(m, m.Movie) }
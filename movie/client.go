@@ -11,8 +11,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a movie client.
-type client struct{ b trakt.BaseClient }
+// Client represents a movie client.
+type Client struct{ b trakt.PathClient }
 
 // Trending returns all movies being watched right now. Movies with the most users are returned first.
 //
@@ -28,8 +28,8 @@ func Trending(params *trakt.FilterListParams) *trakt.TrendingMovieIterator {
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Trending(params *trakt.FilterListParams) *trakt.TrendingMovieIterator {
-	return &trakt.TrendingMovieIterator{Iterator: c.b.NewIterator(http.MethodGet, "/movies/trending", params)}
+func (c *Client) Trending(params *trakt.FilterListParams) *trakt.TrendingMovieIterator {
+	return &trakt.TrendingMovieIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, trakt.NewPath("/movies/trending"), params)}
 }
 
 // Popular returns the most popular movies. Popularity is calculated using the rating
@@ -48,8 +48,8 @@ func Popular(params *trakt.FilterListParams) *trakt.MovieIterator {
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Popular(params *trakt.FilterListParams) *trakt.MovieIterator {
-	return &trakt.MovieIterator{Iterator: c.b.NewIterator(http.MethodGet, "/movies/popular", params)}
+func (c *Client) Popular(params *trakt.FilterListParams) *trakt.MovieIterator {
+	return &trakt.MovieIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, trakt.NewPath("/movies/popular"), params)}
 }
 
 // Played returns the most played (a single user can watch multiple times) movies in the specified time
@@ -70,7 +70,7 @@ func Played(params *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIterat
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Played(params *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIterator {
+func (c *Client) Played(params *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIterator {
 	return c.newTimePeriodIterator("played", params)
 }
 
@@ -90,7 +90,7 @@ func Watched(params *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsItera
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Watched(params *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIterator {
+func (c *Client) Watched(params *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIterator {
 	return c.newTimePeriodIterator("watched", params)
 }
 
@@ -110,7 +110,7 @@ func Collected(params *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIte
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Collected(params *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIterator {
+func (c *Client) Collected(params *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIterator {
 	return c.newTimePeriodIterator("collected", params)
 }
 
@@ -128,8 +128,8 @@ func Anticipated(params *trakt.FilterListParams) *trakt.AnticipatedMovieIterator
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Anticipated(params *trakt.FilterListParams) *trakt.AnticipatedMovieIterator {
-	return &trakt.AnticipatedMovieIterator{Iterator: c.b.NewIterator(http.MethodGet, "/movies/anticipated", params)}
+func (c *Client) Anticipated(params *trakt.FilterListParams) *trakt.AnticipatedMovieIterator {
+	return &trakt.AnticipatedMovieIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, trakt.NewPath("/movies/anticipated"), params)}
 }
 
 // BoxOffice returns the top 10 grossing movies in the U.S. box office last weekend. Updated every Monday morning.
@@ -142,9 +142,9 @@ func BoxOffice(params *trakt.BoxOfficeListParams) *trakt.BoxOfficeMovieIterator
 // BoxOffice returns the top 10 grossing movies in the U.S. box office last weekend. Updated every Monday morning.
 //
 //  - Extended Info
-func (c *client) BoxOffice(params *trakt.BoxOfficeListParams) *trakt.BoxOfficeMovieIterator {
+func (c *Client) BoxOffice(params *trakt.BoxOfficeListParams) *trakt.BoxOfficeMovieIterator {
 	return &trakt.BoxOfficeMovieIterator{
-		BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, "/movies/boxoffice", params),
+		BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, trakt.NewPath("/movies/boxoffice"), params),
 	}
 }
 
@@ -166,9 +166,9 @@ func RecentlyUpdated(params *trakt.RecentlyUpdatedListParams) *trakt.RecentlyUpd
 //
 //  - Pagination
 //  - Extended Info
-func (c *client) RecentlyUpdated(params *trakt.RecentlyUpdatedListParams) *trakt.RecentlyUpdatedMovieIterator {
-	path := trakt.FormatURLPath("/movies/updates/%s", params.StartDate.Format(`2006-01-02`))
-	return &trakt.RecentlyUpdatedMovieIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) RecentlyUpdated(params *trakt.RecentlyUpdatedListParams) *trakt.RecentlyUpdatedMovieIterator {
+	path := trakt.NewPath("/movies/updates/%s", params.StartDate.Format(`2006-01-02`))
+	return &trakt.RecentlyUpdatedMovieIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Get returns a single movie's details.
@@ -181,10 +181,10 @@ func Get(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Movie, error)
 // Get returns a single movie's details.
 //
 //  - Extended Info
-func (c *client) Get(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Movie, error) {
-	path := trakt.FormatURLPath("/movies/%s", id)
+func (c *Client) Get(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Movie, error) {
+	path := trakt.NewPath("/movies/%s", id)
 	mov := &trakt.Movie{}
-	err := c.b.Call(http.MethodGet, path, params, mov)
+	err := c.b.CallPath(http.MethodGet, path, params, mov)
 	return mov, err
 }
 
@@ -194,9 +194,9 @@ func Aliases(id trakt.SearchID, params *trakt.BasicParams) *trakt.AliasIterator
 }
 
 // Aliases returns all title aliases for a movie. Includes country where name is different.
-func (c *client) Aliases(id trakt.SearchID, params *trakt.BasicParams) *trakt.AliasIterator {
-	path := trakt.FormatURLPath("movies/%s/aliases", id)
-	return &trakt.AliasIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+func (c *Client) Aliases(id trakt.SearchID, params *trakt.BasicParams) *trakt.AliasIterator {
+	path := trakt.NewPath("movies/%s/aliases", id)
+	return &trakt.AliasIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
 // Releases returns all releases for a movie including country, certification, release date, release type,
@@ -215,9 +215,9 @@ func Releases(id trakt.SearchID, params *trakt.ReleaseListParams) *trakt.Release
 // The release type can be set to unknown, premiere, limited, theatrical, digital, physical, or tv.
 // The note might have optional info such as the film festival name for a premiere release or
 // Blu-ray specs for a physical release. This info is pulled from TMDB.
-func (c *client) Releases(id trakt.SearchID, params *trakt.ReleaseListParams) *trakt.ReleaseIterator {
-	path := trakt.FormatURLPath("movies/%s/releases/%s", id, params.Country)
-	return &trakt.ReleaseIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+func (c *Client) Releases(id trakt.SearchID, params *trakt.ReleaseListParams) *trakt.ReleaseIterator {
+	path := trakt.NewPath("movies/%s/releases/%s", id, params.Country)
+	return &trakt.ReleaseIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
 // Translations returns all translations for a movie, including language and translated values for
@@ -228,9 +228,9 @@ func Translations(id trakt.SearchID, params *trakt.TranslationListParams) *trakt
 
 // Translations returns all translations for a movie, including language and translated values for
 // title, tagline and overview.
-func (c *client) Translations(id trakt.SearchID, params *trakt.TranslationListParams) *trakt.TranslationIterator {
-	path := trakt.FormatURLPath("movies/%s/translations/%s", id, params.Language)
-	return &trakt.TranslationIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+func (c *Client) Translations(id trakt.SearchID, params *trakt.TranslationListParams) *trakt.TranslationIterator {
+	path := trakt.NewPath("movies/%s/translations/%s", id, params.Language)
+	return &trakt.TranslationIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
 // Comments returns all top level comments for a movie.
@@ -249,9 +249,9 @@ func Comments(id trakt.SearchID, params *trakt.CommentListParams) *trakt.Comment
 // most replies, highest rated, lowest rated, and most plays.
 //
 //  - Pagination
-func (c *client) Comments(id trakt.SearchID, params *trakt.CommentListParams) *trakt.CommentIterator {
-	path := trakt.FormatURLPath("movies/%s/comments/%s", id, params.Sort)
-	return &trakt.CommentIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Comments(id trakt.SearchID, params *trakt.CommentListParams) *trakt.CommentIterator {
+	path := trakt.NewPath("movies/%s/comments/%s", id, params.Sort)
+	return &trakt.CommentIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // WatchingNow returns all users watching this movie right now.
@@ -264,9 +264,9 @@ func WatchingNow(id trakt.SearchID, params *trakt.BasicListParams) *trakt.UserIt
 // WatchingNow returns all users watching this movie right now.
 //
 //  - Extended Info
-func (c *client) WatchingNow(id trakt.SearchID, params *trakt.BasicListParams) *trakt.UserIterator {
-	path := trakt.FormatURLPath("movies/%s/watching", id)
-	return &trakt.UserIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) WatchingNow(id trakt.SearchID, params *trakt.BasicListParams) *trakt.UserIterator {
+	path := trakt.NewPath("movies/%s/watching", id)
+	return &trakt.UserIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Related returns related and similar movies.
@@ -281,9 +281,9 @@ func Related(id trakt.SearchID, params *trakt.ExtendedListParams) *trakt.MovieIt
 //
 //  - Pagination
 //  - Extended Info
-func (c *client) Related(id trakt.SearchID, params *trakt.ExtendedListParams) *trakt.MovieIterator {
-	path := trakt.FormatURLPath("movies/%s/related", id)
-	return &trakt.MovieIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Related(id trakt.SearchID, params *trakt.ExtendedListParams) *trakt.MovieIterator {
+	path := trakt.NewPath("movies/%s/related", id)
+	return &trakt.MovieIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Ratings returns the rating (between 0 and 10) and distribution for a movie.
@@ -292,10 +292,10 @@ func Ratings(id trakt.SearchID, params *trakt.BasicParams) (*trakt.RatingDistrib
 }
 
 // Ratings returns the rating (between 0 and 10) and distribution for a movie.
-func (c *client) Ratings(id trakt.SearchID, params *trakt.BasicParams) (*trakt.RatingDistribution, error) {
-	path := trakt.FormatURLPath("/movies/%s/ratings", id)
+func (c *Client) Ratings(id trakt.SearchID, params *trakt.BasicParams) (*trakt.RatingDistribution, error) {
+	path := trakt.NewPath("/movies/%s/ratings", id)
 	stats := &trakt.RatingDistribution{}
-	err := c.b.Call(http.MethodGet, path, params, stats)
+	err := c.b.CallPath(http.MethodGet, path, params, stats)
 	return stats, err
 }
 
@@ -305,10 +305,10 @@ func Statistics(id trakt.SearchID, params *trakt.BasicParams) (*trakt.Statistics
 }
 
 // Statistics returns lots of movie stats.
-func (c *client) Statistics(id trakt.SearchID, params *trakt.BasicParams) (*trakt.Statistics, error) {
-	path := trakt.FormatURLPath("/movies/%s/stats", id)
+func (c *Client) Statistics(id trakt.SearchID, params *trakt.BasicParams) (*trakt.Statistics, error) {
+	path := trakt.NewPath("/movies/%s/stats", id)
 	stats := &trakt.Statistics{}
-	err := c.b.Call(http.MethodGet, path, params, stats)
+	err := c.b.CallPath(http.MethodGet, path, params, stats)
 	return stats, err
 }
 
@@ -324,9 +324,9 @@ func Lists(id trakt.SearchID, params *trakt.GetListParams) *trakt.ListIterator {
 // by the most popular.
 //
 //  - Pagination
-func (c *client) Lists(id trakt.SearchID, params *trakt.GetListParams) *trakt.ListIterator {
-	path := trakt.FormatURLPath("movies/%s/lists/%s/%s", id, params.ListType, params.SortType)
-	return &trakt.ListIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Lists(id trakt.SearchID, params *trakt.GetListParams) *trakt.ListIterator {
+	path := trakt.NewPath("movies/%s/lists/%s/%s", id, params.ListType, params.SortType)
+	return &trakt.ListIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // People returns all cast and crew for a movie.
@@ -355,23 +355,27 @@ func People(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.CastAndCrew
 // Note: This returns a lot of data, so please only use this extended parameter if you actually need it!
 //
 //  - Extended Info
-func (c *client) People(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.CastAndCrew, error) {
-	path := trakt.FormatURLPath("/movies/%s/people", id)
+func (c *Client) People(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.CastAndCrew, error) {
+	path := trakt.NewPath("/movies/%s/people", id)
 	cc := &trakt.CastAndCrew{}
-	err := c.b.Call(http.MethodGet, path, params, cc)
+	err := c.b.CallPath(http.MethodGet, path, params, cc)
 	return cc, err
 }
 
 // newTimePeriodIterator generates an iterator for a list of movies based on an action and a time period.
 // the time period defaults to WEEKLY if not supplied.
-func (c *client) newTimePeriodIterator(act string, p *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIterator {
+func (c *Client) newTimePeriodIterator(act string, p *trakt.TimePeriodListParams) *trakt.MovieWithStatisticsIterator {
 	var period = trakt.TimePeriodWeekly
 	if p.Period != "" {
 		period = p.Period
 	}
-	path := trakt.FormatURLPath("/movies/%s/%s", act, period)
-	return &trakt.MovieWithStatisticsIterator{Iterator: c.b.NewIterator(http.MethodGet, path, p)}
+	path := trakt.NewPath("/movies/%s/%s", act, period)
+	return &trakt.MovieWithStatisticsIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, p)}
 }
 
+// NewClient initialises a new movie client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new movie client with the currently defined backend configuration.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
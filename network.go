@@ -10,3 +10,6 @@ func (n *NetworkIterator) Network() (*Network, error) {
 	rcv := &Network{}
 	return rcv, n.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (n *NetworkIterator) Iter() *Iter[*Network] { return newIter(n, n.Network) }
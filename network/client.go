@@ -7,16 +7,20 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a network client.
-type client struct{ b trakt.BaseClient }
+// Client represents a network client.
+type Client struct{ b trakt.PathClient }
 
 // List retrieves a list of all TV networks, including the name.
 func List(params *trakt.BasicParams) *trakt.NetworkIterator { return getC().List(params) }
 
 // List retrieves a list of all TV networks, including the name.
-func (c *client) List(params *trakt.BasicParams) *trakt.NetworkIterator {
-	return &trakt.NetworkIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, "/networks", params)}
+func (c *Client) List(params *trakt.BasicParams) *trakt.NetworkIterator {
+	return &trakt.NetworkIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, trakt.NewPath("/networks"), params)}
 }
 
+// NewClient initialises a new network client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new network client with the currently defined backend configuration.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -0,0 +1,92 @@
+package trakt
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestInfo describes a single call to the trakt API as seen by an Observer. The same
+// RequestInfo is supplied to every event for a call, so it can be used to correlate events.
+type RequestInfo struct {
+	// Context the context of the request.
+	Context context.Context
+	// Method the HTTP method of the request.
+	Method string
+	// Path the path of the request, excluding the query string.
+	Path string
+	// PathTemplate the template the path was formatted from, i.e /shows/%s/seasons. This
+	// has a low cardinality so is suitable as a label. If the call was not performed using
+	// a Path, i.e using the methods of a PathClient, the path is used.
+	PathTemplate string
+	// RequestID the request ID from the X-Request-ID header of the latest response.
+	RequestID string
+	// StartedAt the time the call was started.
+	StartedAt time.Time
+}
+
+// ResponseInfo describes the outcome of a call to the trakt API.
+type ResponseInfo struct {
+	// StatusCode the HTTP status code of the final response, this is zero if the request
+	// failed to reach trakt.
+	StatusCode int
+	// Duration the total duration of the call, including any retries.
+	Duration time.Duration
+	// Retries the amount of retries which were performed.
+	Retries int
+	// Cached whether the response was served from the cache.
+	Cached bool
+	// Coalesced whether the response was shared with an identical in-flight request.
+	Coalesced bool
+	// Err the error the call failed with, if any.
+	Err error
+}
+
+// Observer receives events for every call to the trakt API, it can be used to record metrics
+// or trace calls. Events for a single call are received in order; RequestStarted first,
+// followed by any RequestRetried and DecodeFailed events and then ResponseReceived.
+//
+// Implementations must be safe to use across multiple go-routines.
+type Observer interface {
+	// RequestStarted is called before a request is first attempted.
+	RequestStarted(r *RequestInfo)
+	// RequestRetried is called when a failed attempt is going to be retried after waiting for wait.
+	RequestRetried(r *RequestInfo, a *RetryAttempt, wait time.Duration)
+	// DecodeFailed is called when the response could not be decoded.
+	DecodeFailed(r *RequestInfo, err error)
+	// ResponseReceived is called once the call has completed, successfully or not.
+	ResponseReceived(r *RequestInfo, res *ResponseInfo)
+}
+
+// nopObserver an Observer which ignores every event.
+type nopObserver struct{}
+
+// RequestStarted implements Observer interface.
+func (nopObserver) RequestStarted(*RequestInfo) {}
+
+// RequestRetried implements Observer interface.
+func (nopObserver) RequestRetried(*RequestInfo, *RetryAttempt, time.Duration) {}
+
+// DecodeFailed implements Observer interface.
+func (nopObserver) DecodeFailed(*RequestInfo, error) {}
+
+// ResponseReceived implements Observer interface.
+func (nopObserver) ResponseReceived(*RequestInfo, *ResponseInfo) {}
+
+// newRequestInfo generates the RequestInfo for a request.
+func newRequestInfo(r *Request) *RequestInfo {
+	return &RequestInfo{
+		Context:      r.HTTPRequest.Context(),
+		Method:       r.HTTPRequest.Method,
+		Path:         r.HTTPRequest.URL.Path,
+		PathTemplate: r.PathTemplate,
+		StartedAt:    time.Now(),
+	}
+}
+
+// received updates the RequestInfo using the response for an attempt.
+func (r *RequestInfo) received(res *http.Response) {
+	if res != nil {
+		r.RequestID = res.Header.Get(requestIDHeader)
+	}
+}
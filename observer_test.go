@@ -0,0 +1,94 @@
+package trakt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// recordingObserver an Observer which records the template of every started request.
+type recordingObserver struct {
+	nopObserver
+
+	mu        sync.Mutex
+	templates []string
+}
+
+// RequestStarted implements Observer interface.
+func (o *recordingObserver) RequestStarted(r *RequestInfo) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.templates = append(o.templates, r.PathTemplate)
+}
+
+func TestObserver_PathTemplate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		call func(c *Client) error
+		want string
+	}{
+		{
+			name: "formatted",
+			call: func(c *Client) error {
+				return c.CallPath(http.MethodGet, NewPath("/shows/%s/seasons", "the-office"), &BasicParams{}, &[]struct{}{})
+			},
+			want: "/shows/%s/seasons",
+		},
+		{
+			name: "relative",
+			call: func(c *Client) error {
+				return c.CallPath(http.MethodGet, NewPath("movies/%s/aliases", "tron"), &BasicParams{}, &[]struct{}{})
+			},
+			want: "/movies/%s/aliases",
+		},
+		{
+			name: "iterator",
+			call: func(c *Client) error {
+				return c.NewIteratorPath(http.MethodGet, NewPath("/people/%s/lists", "sean"), &ListParams{}).Err()
+			},
+			want: "/people/%s/lists",
+		},
+		{
+			name: "string path",
+			call: func(c *Client) error {
+				return c.Call(http.MethodGet, "/shows/the-office/seasons", &BasicParams{}, &[]struct{}{})
+			},
+			want: "/shows/the-office/seasons",
+		},
+		{
+			name: "base client without paths",
+			call: func(c *Client) error {
+				var b BaseClient = struct{ BaseClient }{c}
+				return Paths(b).CallPath(http.MethodGet, NewPath("/shows/%s", "the-office"), &BasicParams{}, &[]struct{}{})
+			},
+			want: "/shows/the-office",
+		},
+		{
+			name: "backend",
+			call: func(c *Client) error {
+				return c.B.Call(http.MethodGet, "/shows/the-office/seasons", "key", &BasicParams{}, &[]struct{}{})
+			},
+			want: "/shows/the-office/seasons",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &recordingObserver{}
+			c := New("key", &BackendConfig{URL: srv.URL, GetRateLimit: NoRateLimit, Observer: o})
+			if err := tt.call(c); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if len(o.templates) != 1 || o.templates[0] != tt.want {
+				t.Errorf("expected template %s, got %v", tt.want, o.templates)
+			}
+		})
+	}
+}
@@ -24,6 +24,14 @@ type BasicListParams struct {
 
 	Page  *int64 `url:"page,omitempty" json:"-"`
 	Limit *int64 `url:"limit,omitempty" json:"-"`
+
+	// Cursor resumes iterating from a position retrieved using Cursor on an iterator, the
+	// params must match those the cursor was retrieved with. Page and Limit are ignored.
+	Cursor *Cursor `url:"-" json:"-"`
+
+	// Lazy defers retrieving the first page until the first call to Next on the iterator,
+	// by default it is retrieved when the iterator is created.
+	Lazy bool `url:"-" json:"-"`
 }
 
 func (p *BasicListParams) context() context.Context {
@@ -49,6 +57,18 @@ func (p *BasicListParams) setPagination(page, limit int64) {
 	p.Limit = Int64(limit)
 }
 
+func (p *BasicListParams) pagination() (int64, int64) {
+	if p == nil {
+		return 0, 0
+	}
+
+	return deref(p.Page), deref(p.Limit)
+}
+
+func (p *BasicListParams) cursor() *Cursor { return p.Cursor }
+
+func (p *BasicListParams) lazy() bool { return p != nil && p.Lazy }
+
 // setDefaultPagination sets the default pagination values supplied if
 // any of the values are not defined.
 func (p *BasicListParams) setDefaultPagination(page, limit int64) {
@@ -80,6 +100,14 @@ type ListParams struct {
 	Page  *int64 `url:"page,omitempty" json:"-"`
 	Limit *int64 `url:"limit,omitempty" json:"-"`
 
+	// Cursor resumes iterating from a position retrieved using Cursor on an iterator, the
+	// params must match those the cursor was retrieved with. Page and Limit are ignored.
+	Cursor *Cursor `url:"-" json:"-"`
+
+	// Lazy defers retrieving the first page until the first call to Next on the iterator,
+	// by default it is retrieved when the iterator is created.
+	Lazy bool `url:"-" json:"-"`
+
 	// OAuth token to use with the request.
 	// this is passed as a header if supplied.
 	OAuth string `url:"-" json:"-"`
@@ -114,6 +142,18 @@ func (p *ListParams) setPagination(page, limit int64) {
 	p.Limit = Int64(limit)
 }
 
+func (p *ListParams) pagination() (int64, int64) {
+	if p == nil {
+		return 0, 0
+	}
+
+	return deref(p.Page), deref(p.Limit)
+}
+
+func (p *ListParams) cursor() *Cursor { return p.Cursor }
+
+func (p *ListParams) lazy() bool { return p != nil && p.Lazy }
+
 // setDefaultPagination sets the default pagination values supplied if
 // any of the values are not defined.
 func (p *ListParams) setDefaultPagination(page, limit int64) {
@@ -136,6 +176,12 @@ type ListParamsContainer interface {
 	// setDefaultPagination sets the default initial pagination
 	// values to ensure that they are defined.
 	setDefaultPagination(page, limit int64)
+	// pagination returns the current page and limit.
+	pagination() (page, limit int64)
+	// cursor returns the cursor to resume from, if any.
+	cursor() *Cursor
+	// lazy returns whether the first page is retrieved on the first call to Next.
+	lazy() bool
 }
 
 // BasicParams parameters which do not require an OAuth token.
@@ -152,10 +198,26 @@ type BasicParams struct {
 
 	// Headers may be used to provide extra header lines on the HTTP request.
 	Headers http.Header `url:"-" json:"-"`
+
+	// Retry opts a write request (POST, PUT or DELETE) in to being retried after an
+	// intermittent failure. Writes are not retried by default, as retrying a write
+	// which has already been applied could create duplicates.
+	Retry bool `url:"-" json:"-"`
+
+	// DryRun builds write requests (POST, PUT or DELETE) without sending them, the call
+	// returns a *DryRunError containing the request instead. Read requests are still performed.
+	DryRun bool `url:"-" json:"-"`
+
+	// Response is populated with the metadata of the response, such as the status code,
+	// headers and request ID, once the request has completed.
+	Response *ResponseMetadata `url:"-" json:"-"`
 }
 
 func (p *BasicParams) setPagination(_, _ int64)        {}
 func (p *BasicParams) setDefaultPagination(_, _ int64) {}
+func (p *BasicParams) pagination() (int64, int64)      { return 0, 0 }
+func (p *BasicParams) cursor() *Cursor                 { return nil }
+func (p *BasicParams) lazy() bool                      { return false }
 
 func (p *BasicParams) context() context.Context {
 	if p != nil && p.Context != nil {
@@ -175,6 +237,18 @@ func (p *BasicParams) headers() http.Header {
 
 func (p *BasicParams) oauth() string { return `` }
 
+func (p *BasicParams) retryWrite() bool { return p != nil && p.Retry }
+
+func (p *BasicParams) dryRun() bool { return p != nil && p.DryRun }
+
+func (p *BasicParams) responseMetadata() *ResponseMetadata {
+	if p == nil {
+		return nil
+	}
+
+	return p.Response
+}
+
 // Params is the structure that contains the common properties
 // of any *Params structure.
 type Params struct {
@@ -194,10 +268,39 @@ type Params struct {
 	// OAuth token to use with the request.
 	// this is passed as a header if supplied.
 	OAuth string `url:"-" json:"-"`
+
+	// Retry opts a write request (POST, PUT or DELETE) in to being retried after an
+	// intermittent failure. Writes are not retried by default, as retrying a write
+	// which has already been applied could create duplicates, i.e duplicate plays or comments.
+	Retry bool `url:"-" json:"-"`
+
+	// Verify enables verification on write requests which support it, such as the sync
+	// add and remove functions. After a failure which leaves it unknown whether the write
+	// was applied, the resulting state is checked with a read before the request is resent.
+	Verify bool `url:"-" json:"-"`
+
+	// DryRun builds write requests (POST, PUT or DELETE) without sending them, the call
+	// returns a *DryRunError containing the request instead. Read requests are still performed.
+	DryRun bool `url:"-" json:"-"`
+
+	// Response is populated with the metadata of the response, such as the status code,
+	// headers and request ID, once the request has completed.
+	Response *ResponseMetadata `url:"-" json:"-"`
+
+	// ChunkSize is the maximum amount of items sent in a single request by the sync add and remove
+	// functions, larger writes are split into chunks which are sent in order. Defaults to
+	// DefaultChunkSize, a negative value disables chunking.
+	ChunkSize int `url:"-" json:"-"`
+
+	// OnChunk is called with the progress of a sync add or remove function after each chunk is sent.
+	OnChunk func(*ChunkProgress) `url:"-" json:"-"`
 }
 
 func (p *Params) setPagination(_, _ int64)        {}
 func (p *Params) setDefaultPagination(_, _ int64) {}
+func (p *Params) pagination() (int64, int64)      { return 0, 0 }
+func (p *Params) cursor() *Cursor                 { return nil }
+func (p *Params) lazy() bool                      { return false }
 
 func (p *Params) context() context.Context {
 	if p != nil && p.Context != nil {
@@ -223,6 +326,20 @@ func (p *Params) oauth() string {
 	return p.OAuth
 }
 
+// retryWrite determines if the caller has opted in to retrying a write request.
+// verified requests are resent by the client performing the verification instead.
+func (p *Params) retryWrite() bool { return p != nil && p.Retry && !p.Verify }
+
+func (p *Params) dryRun() bool { return p != nil && p.DryRun }
+
+func (p *Params) responseMetadata() *ResponseMetadata {
+	if p == nil {
+		return nil
+	}
+
+	return p.Response
+}
+
 // ParamsContainer is a general interface for which all parameter structs
 // should comply. They achieve this by embedding a Params struct and inheriting
 // its implementation of this interface.
@@ -232,6 +349,83 @@ type ParamsContainer interface {
 	oauth() string
 }
 
+// The following functions expose the values of a ParamsContainer to a custom Backend implemented
+// outside of this package, as the methods of a ParamsContainer are unexported.
+
+// ParamsContext returns the context of the params, a background context is returned if none was set.
+func ParamsContext(p ParamsContainer) context.Context {
+	if p == nil {
+		return context.Background()
+	}
+
+	return p.context()
+}
+
+// ParamsHeaders returns the extra headers defined on the params.
+func ParamsHeaders(p ParamsContainer) http.Header {
+	if p == nil {
+		return nil
+	}
+
+	return p.headers()
+}
+
+// ParamsOAuth returns the OAuth token defined on the params, if any.
+func ParamsOAuth(p ParamsContainer) string {
+	if p == nil {
+		return ``
+	}
+
+	return p.oauth()
+}
+
+// ParamsPagination returns the page and limit defined on the params. Iterators define
+// the page and limit before each page is retrieved, so these are always set for a call
+// made by an iterator.
+func ParamsPagination(p ListParamsContainer) (page, limit int64) {
+	if p == nil {
+		return 0, 0
+	}
+
+	return p.pagination()
+}
+
+// writeRetrier is implemented by parameters which can opt a write request
+// in to being retried.
+type writeRetrier interface {
+	// retryWrite returns whether a failed write request can be retried.
+	retryWrite() bool
+}
+
+// canRetryWrite determines if the supplied parameters have opted in to
+// retrying a failed write request.
+func canRetryWrite(p ParamsContainer) bool {
+	switch r := p.(type) {
+	case writeRetrier:
+		return r.retryWrite()
+	}
+
+	return false
+}
+
+// metadataReceiver is implemented by parameters which can receive the
+// metadata of the response.
+type metadataReceiver interface {
+	// responseMetadata returns the receiver for the response metadata, if any.
+	responseMetadata() *ResponseMetadata
+}
+
+// responseMetadataFor retrieves the receiver for the response metadata from
+// the supplied parameters, this is nil if the metadata has not been requested.
+func responseMetadataFor(p ParamsContainer) *ResponseMetadata {
+	switch r := p.(type) {
+	case metadataReceiver:
+		return r.responseMetadata()
+	}
+
+	return nil
+}
+
 // parseInt helper function to parse a uint from a string.
 func parseInt(s string) int64 {
 	i, _ := strconv.Atoi(s)
@@ -242,3 +436,12 @@ func parseInt(s string) int64 {
 func Int64(v int64) *int64 {
 	return &v
 }
+
+// deref returns the value of an int64 pointer, or zero if it is nil.
+func deref(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+
+	return *v
+}
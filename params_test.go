@@ -0,0 +1,77 @@
+package trakt_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+type contextKey struct{}
+
+// fakeBackend is a backend implemented outside of the package, which records the values
+// read from the params of the latest call.
+type fakeBackend struct {
+	ctx         context.Context
+	headers     http.Header
+	token       string
+	page, limit int64
+}
+
+func (f *fakeBackend) Call(_, _, _ string, p trakt.ParamsContainer, v interface{}) error {
+	f.ctx, f.headers, f.token = trakt.ParamsContext(p), trakt.ParamsHeaders(p), trakt.ParamsOAuth(p)
+	return json.Unmarshal([]byte(`{}`), v)
+}
+
+func (f *fakeBackend) CallWithFrame(_, _, _ string, p trakt.ParamsContainer, fr trakt.Frame) error {
+	f.ctx, f.headers, f.token = trakt.ParamsContext(p), trakt.ParamsHeaders(p), trakt.ParamsOAuth(p)
+	if lp, ok := p.(trakt.ListParamsContainer); ok {
+		f.page, f.limit = trakt.ParamsPagination(lp)
+	}
+
+	h := http.Header{}
+	h.Set("X-Pagination-Page", strconv.FormatInt(f.page, 10))
+	h.Set("X-Pagination-Limit", strconv.FormatInt(f.limit, 10))
+	h.Set("X-Pagination-Page-Count", "1")
+	h.Set("X-Pagination-Item-Count", "0")
+	if err := json.Unmarshal([]byte(`[]`), fr.Receiver()); err != nil {
+		return err
+	}
+
+	return fr.UnmarshalHeaders(h)
+}
+
+func (f *fakeBackend) OAuthURL() string { return "" }
+
+func TestParamsAccessors(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey{}, "value")
+	headers := http.Header{"X-Custom": []string{"value"}}
+
+	b := &fakeBackend{}
+	c := trakt.NewWithBackend("key", b)
+
+	p := &trakt.Params{Context: ctx, Headers: headers, OAuth: "token"}
+	if err := c.Call(http.MethodGet, "/sync/last_activities", p, &struct{}{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if b.ctx.Value(contextKey{}) != "value" || b.headers.Get("X-Custom") != "value" || b.token != "token" {
+		t.Errorf("expected the values of the params to be readable, got %v %v %q", b.ctx, b.headers, b.token)
+	}
+
+	lp := &trakt.ListParams{OAuth: "list-token", Limit: trakt.Int64(5)}
+	if err := c.NewIterator(http.MethodGet, "/sync/history", lp).Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if b.token != "list-token" || b.page != 1 || b.limit != 5 {
+		t.Errorf("expected token list-token, page 1 and limit 5, got %q, %d and %d", b.token, b.page, b.limit)
+	}
+
+	if ctx := trakt.ParamsContext(nil); ctx == nil {
+		t.Error("expected a background context for nil params")
+	}
+}
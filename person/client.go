@@ -9,8 +9,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a person client.
-type client struct{ b trakt.BaseClient }
+// Client represents a person client.
+type Client struct{ b trakt.PathClient }
 
 // Get returns a single person's details.
 //
@@ -20,10 +20,10 @@ func Get(id trakt.SearchID, p *trakt.ExtendedParams) (*trakt.Person, error) { re
 // Get returns a single person's details.
 //
 //  - Extended Info
-func (c *client) Get(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Person, error) {
+func (c *Client) Get(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Person, error) {
 	p := &trakt.Person{}
-	path := trakt.FormatURLPath("/people/%s", id)
-	err := c.b.Call(http.MethodGet, path, params, p)
+	path := trakt.NewPath("/people/%s", id)
+	err := c.b.CallPath(http.MethodGet, path, params, p)
 	return p, err
 }
 
@@ -49,10 +49,10 @@ func MovieCredits(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Credi
 // Each of those members will have a jobs array and a standard movie object.
 //
 //  - Extended Info
-func (c *client) MovieCredits(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Credits, error) {
+func (c *Client) MovieCredits(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Credits, error) {
 	cr := &trakt.Credits{}
-	path := trakt.FormatURLPath("/people/%s/movies", id)
-	err := c.b.Call(http.MethodGet, path, params, cr)
+	path := trakt.NewPath("/people/%s/movies", id)
+	err := c.b.CallPath(http.MethodGet, path, params, cr)
 	return cr, err
 }
 
@@ -78,10 +78,10 @@ func ShowCredits(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Credit
 // Each of those members will have a jobs array and a standard movie object.
 //
 //  - Extended Info
-func (c *client) ShowCredits(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Credits, error) {
+func (c *Client) ShowCredits(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Credits, error) {
 	cr := &trakt.Credits{}
-	path := trakt.FormatURLPath("/people/%s/shows", id)
-	err := c.b.Call(http.MethodGet, path, params, cr)
+	path := trakt.NewPath("/people/%s/shows", id)
+	err := c.b.CallPath(http.MethodGet, path, params, cr)
 	return cr, err
 }
 
@@ -99,10 +99,14 @@ func Lists(id trakt.SearchID, params *trakt.GetListParams) *trakt.ListIterator {
 // By default, personal lists are returned sorted by the most popular.
 //
 //  - Pagination
-func (c *client) Lists(id trakt.SearchID, params *trakt.GetListParams) *trakt.ListIterator {
-	path := trakt.FormatURLPath("people/%s/lists/%s/%s", id, params.ListType, params.SortType)
-	return &trakt.ListIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Lists(id trakt.SearchID, params *trakt.GetListParams) *trakt.ListIterator {
+	path := trakt.NewPath("people/%s/lists/%s/%s", id, params.ListType, params.SortType)
+	return &trakt.ListIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
+// NewClient initialises a new person client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new person client with the currently defined backend configuration.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -33,3 +33,6 @@ func (p *PlaybackIterator) Playback() (*Playback, error) {
 	rcv := &Playback{}
 	return rcv, p.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (p *PlaybackIterator) Iter() *Iter[*Playback] { return newIter(p, p.Playback) }
@@ -0,0 +1,110 @@
+package trakt
+
+import "reflect"
+
+// prefetched a page which is being fetched ahead of the current page.
+type prefetched struct {
+	// done is closed once the page has been fetched.
+	done   chan struct{}
+	window iterationFrame
+	err    error
+}
+
+// Prefetch allows us to fetch up to n pages ahead of the current page concurrently.
+func (it *iter) Prefetch(n int) {
+	it.withLock(func() {
+		it.prefetch = n
+		it.schedule()
+	})
+}
+
+// schedule starts fetching the pages after the current page, up to the prefetch
+// limit, which are not already being fetched. The lock must be held when calling.
+func (it *iter) schedule() {
+	if it.prefetch <= 0 || it.err != nil || it.meta == nil || !it.loaded {
+		return
+	}
+
+	last := it.meta.currentPage + int64(it.prefetch)
+	if last > it.meta.totalPages {
+		last = it.meta.totalPages
+	}
+
+	if it.limit != NoLimit && last > it.limit {
+		last = it.limit
+	}
+
+	if it.ahead == nil {
+		it.ahead = make(map[int64]*prefetched)
+	}
+
+	for page := it.meta.currentPage + 1; page <= last; page++ {
+		if _, ok := it.ahead[page]; ok {
+			continue
+		}
+
+		cp, ok := cloneListParams(it.listParams)
+		if !ok {
+			return
+		}
+
+		p := &prefetched{done: make(chan struct{})}
+		it.ahead[page] = p
+		cp.setPagination(page, it.meta.limit)
+		go func(q queryFunc) {
+			defer close(p.done)
+			p.window, p.err = q(cp)
+		}(it.query)
+	}
+}
+
+// nextPrefetched moves to the next page, waiting for it to be fetched if it has not been already.
+// false is returned if the page is not being prefetched, in which case it should be fetched normally.
+func (it *iter) nextPrefetched(page int64) bool {
+	var p *prefetched
+	it.withLock(func() {
+		it.schedule()
+		if p = it.ahead[page]; p != nil {
+			delete(it.ahead, page)
+		}
+	})
+
+	if p == nil {
+		return false
+	}
+
+	<-p.done
+	it.withLock(func() {
+		// keep the params in step with the current page.
+		it.listParams.setPagination(page, it.meta.limit)
+
+		if it.err = p.err; it.err != nil {
+			// the remaining pages are discarded once they have been fetched.
+			it.ahead = nil
+			return
+		}
+
+		if rcv := p.window.rcv(); rcv != nil {
+			it.setValues(*rcv)
+		}
+
+		it.meta = p.window.meta()
+		it.schedule()
+	})
+
+	return true
+}
+
+// cloneListParams returns a shallow copy of the params so that a page can be fetched
+// concurrently with a different page number. false is returned if the params cannot be copied.
+func cloneListParams(p ListParamsContainer) (ListParamsContainer, bool) {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, false
+	}
+
+	cp := reflect.New(v.Elem().Type())
+	cp.Elem().Set(v.Elem())
+	c, ok := cp.Interface().(ListParamsContainer)
+	return c, ok
+}
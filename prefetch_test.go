@@ -0,0 +1,162 @@
+package trakt_test
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	gosync "sync"
+	"testing"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/sync"
+	"github.com/jacklaaa89/trakt/trakttest"
+)
+
+// pageRecorder a middleware which records the pages which were requested, failing the request for fail.
+type pageRecorder struct {
+	mu    gosync.Mutex
+	pages []string
+	fail  string
+}
+
+func (p *pageRecorder) middleware(next trakt.Handler) trakt.Handler {
+	return func(r *trakt.Request, v interface{}) error {
+		page := r.HTTPRequest.URL.Query().Get("page")
+
+		p.mu.Lock()
+		p.pages = append(p.pages, page)
+		p.mu.Unlock()
+
+		if page == p.fail {
+			return &trakt.Error{HTTPStatusCode: http.StatusInternalServerError, Code: trakt.ErrorCodeServerError}
+		}
+
+		return next(r, v)
+	}
+}
+
+// requested returns the sorted pages which were requested, ignoring any of the supplied pages.
+func (p *pageRecorder) requested(ignore ...string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var pages []string
+	for _, page := range p.pages {
+		if !contains(ignore, page) {
+			pages = append(pages, page)
+		}
+	}
+
+	sort.Strings(pages)
+	return pages
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// seedHistory adds n movies to the history of the user, returning their titles in the order
+// the history is listed, most recently watched first.
+func seedHistory(t *testing.T, srv *trakttest.Server, token string, n int) []string {
+	t.Helper()
+
+	watchedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var titles []string
+	var movies []*trakt.MediaHistoryParams
+	for i := n - 1; i >= 0; i-- {
+		title := fmt.Sprintf("Movie %02d", i)
+		m := srv.AddMovie(&trakttest.Movie{Title: title, Year: 2000})
+		movies = append(movies, &trakt.MediaHistoryParams{IDs: m.IDs, WatchedAt: watchedAt.Add(time.Duration(i) * time.Hour)})
+		titles = append(titles, title)
+	}
+
+	_, err := sync.NewClient(trakt.New("client-id", srv.BackendConfig())).AddToHistory(&trakt.AddToHistoryParams{
+		Params: trakt.Params{OAuth: token},
+		Movies: movies,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return titles
+}
+
+// historyTitles iterates the history of the user, returning the titles of the movies.
+func historyTitles(srv *trakttest.Server, token string, rec *pageRecorder, configure func(it trakt.Iterator)) ([]string, error) {
+	cfg := srv.BackendConfig()
+	cfg.Middleware = []trakt.Middleware{rec.middleware}
+
+	it := sync.NewClient(trakt.New("client-id", cfg)).History(&trakt.ListHistoryParams{
+		ListParams: trakt.ListParams{OAuth: token, Limit: trakt.Int64(5), Lazy: true},
+	})
+	configure(it)
+
+	var titles []string
+	for it.Next() {
+		rcv := &historyEntry{}
+		if err := it.Scan(rcv); err != nil {
+			return titles, err
+		}
+
+		titles = append(titles, rcv.Movie.Title)
+	}
+
+	return titles, it.Err()
+}
+
+func TestIterator_Prefetch(t *testing.T) {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	token := srv.Authorize("sean")
+	want := seedHistory(t, srv, token, 23)
+
+	tests := []struct {
+		name   string
+		fail   string
+		limit  int64
+		titles int
+		pages  []string
+		// inFlight pages which may have been requested before the error was seen.
+		inFlight []string
+		err      bool
+	}{
+		{name: "ordering", titles: 23, pages: []string{"1", "2", "3", "4", "5"}},
+		{name: "error stops prefetch", fail: "3", titles: 10, pages: []string{"1", "2", "3"}, inFlight: []string{"4"}, err: true},
+		{name: "page limit", limit: 2, titles: 10, pages: []string{"1", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &pageRecorder{fail: tt.fail}
+			titles, err := historyTitles(srv, token, rec, func(it trakt.Iterator) {
+				if tt.limit > 0 {
+					it.PageLimit(tt.limit)
+				}
+
+				it.Prefetch(2)
+			})
+
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error to be %v, got %v", tt.err, err)
+			}
+
+			if !reflect.DeepEqual(titles, want[:tt.titles]) {
+				t.Errorf("expected %v, got %v", want[:tt.titles], titles)
+			}
+
+			if pages := rec.requested(tt.inFlight...); !reflect.DeepEqual(pages, tt.pages) {
+				t.Errorf("expected pages %v to be requested, got %v", tt.pages, pages)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/queue"
+	"github.com/jacklaaa89/trakt/sync"
+	"github.com/jacklaaa89/trakt/trakttest"
+)
+
+func ExampleQueue() {
+	dir, err := ioutil.TempDir("", "queue")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+
+	q, err := queue.Open(filepath.Join(dir, "trakt.queue"))
+	if err != nil {
+		panic(err)
+	}
+	defer q.Close()
+
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	srv.AddMovie(&trakttest.Movie{Title: "Tron: Legacy", Year: 2010})
+	token := srv.Authorize("sean")
+
+	// trakt cannot be reached while the server is offline.
+	offline := trakttest.NewServer()
+	cfg := offline.BackendConfig()
+	cfg.Middleware = []trakt.Middleware{q.Middleware()}
+	offline.Close()
+
+	_, err = sync.NewClient(trakt.New("client-id", cfg)).AddToHistory(&trakt.AddToHistoryParams{
+		Params: trakt.Params{OAuth: token},
+		Movies: []*trakt.MediaHistoryParams{{IDs: trakt.MediaIDs{Slug: "tron-legacy-2010"}}},
+	})
+
+	fmt.Println(errors.Is(err, queue.ErrQueued), q.Len())
+
+	outcomes, err := q.Replay(context.Background(), trakt.New("client-id", srv.BackendConfig()))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, o := range outcomes {
+		fmt.Println(o.Entry.Path, o.Added.Added.Movies, o.Err)
+	}
+
+	fmt.Println(q.Len())
+	// Output:
+	// true 1
+	// /sync/history 1 <nil>
+	// 0
+}
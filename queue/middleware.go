@@ -0,0 +1,102 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// ErrQueued is matched by the error returned when a write has been queued.
+var ErrQueued = errors.New("queue: write queued")
+
+// QueuedError is returned when a write is queued rather than being sent to trakt.
+type QueuedError struct {
+	// Entry the queued entry.
+	Entry *Entry
+	// Err the error which caused the write to be queued, this is nil if the write was
+	// queued because earlier writes are still pending.
+	Err error
+}
+
+// Error implements error interface.
+func (q *QueuedError) Error() string {
+	if q.Err == nil {
+		return fmt.Sprintf("queue: %s %s queued behind pending writes", q.Entry.Method, q.Entry.Path)
+	}
+
+	return fmt.Sprintf("queue: %s %s queued: %v", q.Entry.Method, q.Entry.Path, q.Err)
+}
+
+// Unwrap returns the error which caused the write to be queued.
+func (q *QueuedError) Unwrap() error { return q.Err }
+
+// Is allows a QueuedError to match ErrQueued using errors.Is.
+func (q *QueuedError) Is(target error) bool { return target == ErrQueued }
+
+// Middleware returns a middleware which queues scrobbles and sync writes which fail because trakt
+// could not be reached. While writes are pending, new writes are queued without being sent so that
+// they are applied in order. Dry-run requests are never queued.
+//
+// Only writes which failed before being sent, because the connection to trakt could not be made or
+// the circuit breaker is open, are queued. A write which timed out or failed once it was sent may
+// have been applied, replaying it could apply it twice so the error is returned instead.
+func (q *Queue) Middleware() trakt.Middleware {
+	return func(next trakt.Handler) trakt.Handler {
+		return func(r *trakt.Request, v interface{}) error {
+			path, ok := queuePath(r.HTTPRequest.Method, r.HTTPRequest.URL.Path)
+			if _, replaying := r.Params.(*replayParams); !ok || replaying || r.DryRun {
+				return next(r, v)
+			}
+
+			if q.Len() > 0 {
+				return q.queue(r, path, nil)
+			}
+
+			err := next(r, v)
+			if !isUnreachable(err) {
+				return err
+			}
+
+			return q.queue(r, path, err)
+		}
+	}
+}
+
+// queue appends the request to the queue.
+func (q *Queue) queue(r *trakt.Request, path string, cause error) error {
+	oauth := strings.TrimPrefix(r.HTTPRequest.Header.Get("Authorization"), "Bearer ")
+	e, err := q.enqueue(r.HTTPRequest.Method, path, oauth, r.Body)
+	if err != nil {
+		if cause != nil {
+			return cause
+		}
+
+		return err
+	}
+
+	return &QueuedError{Entry: e, Err: cause}
+}
+
+// isUnreachable determines if an error means that trakt could not be reached, so the write
+// was never sent and can be queued. This is the case when the circuit breaker is open, or
+// when the connection could not be made because the host could not be resolved or dialled.
+func isUnreachable(err error) bool {
+	if errors.Is(err, trakt.ErrCircuitOpen) {
+		return true
+	}
+
+	if !errors.Is(err, trakt.ErrNetwork) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,184 @@
+package queue_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/queue"
+	"github.com/jacklaaa89/trakt/sync"
+	"github.com/jacklaaa89/trakt/trakttest"
+)
+
+func TestQueue_Middleware(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	offline := httptest.NewServer(http.NotFoundHandler())
+	offline.Close()
+
+	tests := []struct {
+		name   string
+		url    string
+		config func(c *trakt.BackendConfig)
+		params trakt.Params
+		calls  int
+		queued bool
+		want   error
+	}{
+		{name: "unreachable", url: offline.URL, calls: 1, queued: true, want: trakt.ErrNetwork},
+		{
+			name:   "timed out once sent",
+			url:    slow.URL,
+			config: func(c *trakt.BackendConfig) { c.HTTPClient = &http.Client{Timeout: 50 * time.Millisecond} },
+			calls:  1,
+			want:   trakt.ErrNetwork,
+		},
+		{name: "unavailable", url: unavailable.URL, calls: 1, want: trakt.ErrServerUnavailable},
+		{
+			name: "circuit open",
+			url:  unavailable.URL,
+			config: func(c *trakt.BackendConfig) {
+				c.CircuitBreaker = &trakt.CircuitBreaker{FailureRatio: 0.5, MinRequests: 1, OpenTimeout: time.Minute}
+			},
+			calls:  2,
+			queued: true,
+			want:   trakt.ErrCircuitOpen,
+		},
+		{name: "dry-run params", url: offline.URL, params: trakt.Params{DryRun: true}, calls: 1, want: trakt.ErrDryRun},
+		{
+			name:   "dry-run config",
+			url:    offline.URL,
+			config: func(c *trakt.BackendConfig) { c.DryRun = true },
+			calls:  1,
+			want:   trakt.ErrDryRun,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := queue.Open(filepath.Join(t.TempDir(), "trakt.queue"))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			defer q.Close()
+
+			cfg := &trakt.BackendConfig{
+				URL:            tt.url,
+				WriteRateLimit: trakt.NoRateLimit,
+				Middleware:     []trakt.Middleware{q.Middleware()},
+			}
+
+			if tt.config != nil {
+				tt.config(cfg)
+			}
+
+			c := sync.NewClient(trakt.New("client-id", cfg))
+			for i := 0; i < tt.calls; i++ {
+				params := tt.params
+				params.OAuth = "token"
+				_, err = c.AddToCollection(&trakt.AddToCollectionParams{
+					Params: params,
+					Movies: []*trakt.MediaCollectionParams{{IDs: trakt.MediaIDs{Slug: "tron-legacy-2010"}}},
+				})
+			}
+
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+
+			if queued := errors.Is(err, queue.ErrQueued); queued != tt.queued || q.Len() != map[bool]int{true: 1}[tt.queued] {
+				t.Errorf("expected queued to be %v, got %v with %d pending", tt.queued, queued, q.Len())
+			}
+		})
+	}
+}
+
+func TestQueue_MiddlewareChunks(t *testing.T) {
+	q, err := queue.Open(filepath.Join(t.TempDir(), "trakt.queue"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer q.Close()
+
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	token := srv.Authorize("sean")
+	var movies []*trakt.MediaHistoryParams
+	for i := 0; i < 25; i++ {
+		m := srv.AddMovie(&trakttest.Movie{Title: fmt.Sprintf("Movie %d", i), Year: 2000})
+		movies = append(movies, &trakt.MediaHistoryParams{IDs: m.IDs})
+	}
+
+	offline := trakttest.NewServer()
+	cfg := offline.BackendConfig()
+	cfg.WriteRateLimit = trakt.NoRateLimit
+	cfg.Middleware = []trakt.Middleware{q.Middleware()}
+	offline.Close()
+
+	var chunks int
+	_, err = sync.NewClient(trakt.New("client-id", cfg)).AddToHistory(&trakt.AddToHistoryParams{
+		Params: trakt.Params{OAuth: token, ChunkSize: 10, OnChunk: func(*trakt.ChunkProgress) { chunks++ }},
+		Movies: movies,
+	})
+
+	if !errors.Is(err, queue.ErrQueued) {
+		t.Fatalf("expected %v, got %v", queue.ErrQueued, err)
+	}
+
+	if chunks != 3 || q.Len() != 3 {
+		t.Fatalf("expected every chunk to be queued, got %d of %d chunks queued", q.Len(), chunks)
+	}
+
+	var items int
+	for _, e := range q.Pending() {
+		var body struct {
+			Movies []json.RawMessage `json:"movies"`
+		}
+
+		if err := json.Unmarshal(e.Body, &body); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		items += len(body.Movies)
+	}
+
+	if items != len(movies) {
+		t.Errorf("expected %d items to be queued, got %d", len(movies), items)
+	}
+
+	cfg = srv.BackendConfig()
+	cfg.WriteRateLimit = trakt.NoRateLimit
+	outcomes, err := q.Replay(context.Background(), trakt.New("client-id", cfg))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var added int64
+	for _, o := range outcomes {
+		if o.Err != nil {
+			t.Fatalf("expected no error, got %v", o.Err)
+		}
+
+		added += o.Added.Added.Movies
+	}
+
+	if added != int64(len(movies)) || q.Len() != 0 {
+		t.Errorf("expected %d movies to be added on replay, got %d with %d pending", len(movies), added, q.Len())
+	}
+}
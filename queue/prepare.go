@@ -0,0 +1,133 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// scrobbleThreshold the progress at which trakt treats a stopped scrobble as watched.
+const scrobbleThreshold = 80
+
+// paths the paths of the writes which are queued.
+var paths = []string{
+	"/scrobble/stop",
+	"/sync/history/remove", "/sync/history",
+	"/sync/collection/remove", "/sync/collection",
+	"/sync/ratings/remove", "/sync/ratings",
+	"/sync/watchlist/remove", "/sync/watchlist",
+}
+
+// timestampFields the field which is stamped with the time an item was queued for each path.
+var timestampFields = map[string]string{
+	"/sync/history":    "watched_at",
+	"/sync/collection": "collected_at",
+	"/sync/ratings":    "rated_at",
+}
+
+// mediaTypes the keys which contain the items in a sync body, or their children.
+var mediaTypes = []string{"movies", "shows", "seasons", "episodes"}
+
+// queuePath determines the path of a write which can be queued from the URL path of a request.
+func queuePath(method, urlPath string) (string, bool) {
+	if method != http.MethodPost {
+		return "", false
+	}
+
+	for _, p := range paths {
+		if strings.HasSuffix(urlPath, p) {
+			return p, true
+		}
+	}
+
+	return "", false
+}
+
+// prepare prepares a write to be queued, ensuring it keeps its original timestamps when it is
+// replayed. Items without a timestamp are stamped with the time they were queued, and a finished
+// scrobble is converted into a history entry as the time of a scrobble cannot be supplied.
+func prepare(method, path string, body []byte, at time.Time) (string, string, json.RawMessage) {
+	var m map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&m); err != nil {
+		return method, path, body
+	}
+
+	ts := at.Format(time.RFC3339Nano)
+	if path == "/scrobble/stop" {
+		h, ok := scrobbleToHistory(m, ts)
+		if !ok {
+			return method, path, body
+		}
+
+		m, path = h, "/sync/history"
+	}
+
+	field, ok := timestampFields[path]
+	if !ok {
+		return method, path, body
+	}
+
+	stamp(m, field, ts)
+	b, err := json.Marshal(m)
+	if err != nil {
+		return method, path, body
+	}
+
+	return method, path, b
+}
+
+// scrobbleToHistory converts the body of a stopped scrobble into the body of a history entry if
+// the progress means it would have been scrobbled, as opposed to being treated as a pause.
+func scrobbleToHistory(m map[string]interface{}, ts string) (map[string]interface{}, bool) {
+	n, _ := m["progress"].(json.Number)
+	if progress, err := n.Float64(); err != nil || progress < scrobbleThreshold {
+		return nil, false
+	}
+
+	for key, plural := range map[string]string{"movie": "movies", "episode": "episodes"} {
+		item, ok := m[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		item["watched_at"] = ts
+		return map[string]interface{}{plural: []interface{}{item}}, true
+	}
+
+	return nil, false
+}
+
+// stamp sets the timestamp field on every item which does not define one. Children inherit the
+// timestamp of their parent, i.e the episodes of a show.
+func stamp(m map[string]interface{}, field, ts string) {
+	for _, key := range mediaTypes {
+		items, ok := m[key].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, i := range items {
+			item, ok := i.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if v, _ := item[field].(string); v == "" || isZeroTime(v) {
+				item[field] = ts
+			}
+
+			stamp(item, field, item[field].(string))
+		}
+	}
+}
+
+// isZeroTime determines if a timestamp represents the zero time, which is what
+// an unset time.Time is encoded as.
+func isZeroTime(v string) bool {
+	t, err := time.Parse(time.RFC3339Nano, v)
+	return err == nil && t.IsZero()
+}
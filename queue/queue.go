@@ -0,0 +1,326 @@
+// Package queue provides a durable write queue which records scrobbles and sync writes made while
+// trakt is unreachable and replays them in order once it is reachable again.
+//
+// The queue plugs into the backend configuration as a middleware:
+//
+//  q, err := queue.Open("/var/lib/media-centre/trakt.queue")
+//  if err != nil {
+//  	return err
+//  }
+//  defer q.Close()
+//
+//  c := trakt.New(clientID, &trakt.BackendConfig{Middleware: []trakt.Middleware{q.Middleware()}})
+//
+// A write which fails because trakt could not be reached is appended to the queue and the call
+// returns an error matching ErrQueued. A write is only queued if it was never sent, a write which
+// fails once sent, i.e by timing out, may have been applied so is never queued. While writes are
+// pending every subsequent write is also queued, so that writes are always applied in the order they
+// were made. Pending writes are replayed using Replay or Run:
+//
+//  outcomes, err := q.Replay(ctx, c)
+//
+// An entry is only removed from the queue once trakt accepts it or definitively rejects it, an entry
+// which fails with a server error or is rate limited while replaying stays queued.
+//
+// Items which are added to the history, collection or ratings without a timestamp are stamped with
+// the time they were queued, and a scrobble which is stopped at 80% or more is queued as a history
+// entry watched at the time it was stopped. This keeps the original timestamps when a write is
+// replayed later.
+//
+// The queue is stored as an append-only file containing one JSON record per line. As the file
+// contains the OAuth token for each write, it is created readable by the current user only.
+package queue
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// file permissions for the queue file, it contains OAuth tokens.
+const fileMode = 0600
+
+// record operations.
+const (
+	opEnqueue = "enqueue"
+	opDone    = "done"
+)
+
+// ErrClosed is returned when using a queue which has been closed.
+var ErrClosed = errors.New("queue: closed")
+
+// Entry is a single write which has been queued.
+type Entry struct {
+	// ID the unique, increasing ID of the entry.
+	ID uint64 `json:"id"`
+	// Method the HTTP method of the write.
+	Method string `json:"method"`
+	// Path the path of the write, i.e /sync/history.
+	Path string `json:"path"`
+	// OAuth the OAuth token the write was made with.
+	OAuth string `json:"oauth,omitempty"`
+	// Body the JSON encoded body of the write.
+	Body json.RawMessage `json:"body"`
+	// QueuedAt the time the write was queued.
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// record a single line in the queue file.
+type record struct {
+	Op    string `json:"op"`
+	Entry *Entry `json:"entry,omitempty"`
+	ID    uint64 `json:"id,omitempty"`
+}
+
+// Queue is a durable queue of writes which are waiting to be sent to trakt.
+type Queue struct {
+	mu sync.Mutex
+	// replay ensures only a single replay is performed at a time.
+	replay sync.Mutex
+
+	// path the path to the queue file.
+	path string
+	// f the queue file, opened for appending.
+	f *os.File
+	// pending the entries which have not been replayed, in the order they were queued.
+	pending []*Entry
+	// seq the ID of the last entry queued.
+	seq uint64
+	// now is used to retrieve the current time.
+	now func() time.Time
+}
+
+// Open opens the queue stored in the file at path, creating it if it does not exist. Any entries
+// which were pending when the queue was last closed are loaded and the file is compacted so that
+// it only contains the pending entries.
+func Open(path string) (*Queue, error) {
+	q := &Queue{path: path, now: time.Now}
+	if err := q.load(); err != nil {
+		return nil, err
+	}
+
+	if err := q.compact(); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, fileMode)
+	if err != nil {
+		return nil, err
+	}
+
+	q.f = f
+	return q, nil
+}
+
+// Len returns the amount of pending entries.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Pending returns a copy of the pending entries, in the order they were queued.
+func (q *Queue) Pending() []*Entry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entries := make([]*Entry, len(q.pending))
+	for i, e := range q.pending {
+		cp := *e
+		entries[i] = &cp
+	}
+
+	return entries
+}
+
+// Close closes the queue file, pending entries are retained and loaded when the queue is reopened.
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.f == nil {
+		return nil
+	}
+
+	err := q.f.Close()
+	q.f = nil
+	return err
+}
+
+// enqueue appends a new entry to the queue.
+func (q *Queue) enqueue(method, path, oauth string, body []byte) (*Entry, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.f == nil {
+		return nil, ErrClosed
+	}
+
+	e := &Entry{ID: q.seq + 1, Method: method, Path: path, OAuth: oauth, QueuedAt: q.now().UTC()}
+	e.Method, e.Path, e.Body = prepare(e.Method, e.Path, body, e.QueuedAt)
+
+	if err := q.append(&record{Op: opEnqueue, Entry: e}); err != nil {
+		return nil, err
+	}
+
+	q.seq = e.ID
+	q.pending = append(q.pending, e)
+	return e, nil
+}
+
+// done marks the entry as no longer pending.
+func (q *Queue) done(id uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.f == nil {
+		return ErrClosed
+	}
+
+	if err := q.append(&record{Op: opDone, ID: id}); err != nil {
+		return err
+	}
+
+	for i, e := range q.pending {
+		if e.ID == id {
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// next retrieves the oldest pending entry.
+func (q *Queue) next() (*Entry, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.pending) == 0 {
+		return nil, false
+	}
+
+	return q.pending[0], true
+}
+
+// append writes a record to the queue file, syncing it to disk before returning.
+func (q *Queue) append(r *record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+
+	return q.f.Sync()
+}
+
+// load reads the queue file, rebuilding the pending entries. A partially written
+// final record, which occurs if we crashed while writing it, is ignored.
+func (q *Queue) load() error {
+	f, err := os.Open(q.path)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var rec record
+			if jErr := json.Unmarshal(line, &rec); jErr == nil {
+				q.apply(&rec)
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// apply applies a record read from the queue file.
+func (q *Queue) apply(r *record) {
+	switch r.Op {
+	case opEnqueue:
+		if r.Entry == nil {
+			return
+		}
+
+		q.pending = append(q.pending, r.Entry)
+		if r.Entry.ID > q.seq {
+			q.seq = r.Entry.ID
+		}
+	case opDone:
+		for i, e := range q.pending {
+			if e.ID == r.ID {
+				q.pending = append(q.pending[:i], q.pending[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// compact rewrites the queue file so that it only contains the pending entries.
+func (q *Queue) compact() error {
+	var buf bytes.Buffer
+	for _, e := range q.pending {
+		b, err := json.Marshal(&record{Op: opEnqueue, Entry: e})
+		if err != nil {
+			return err
+		}
+
+		buf.Write(append(b, '\n'))
+	}
+
+	dir := filepath.Dir(q.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that a crash never loses the queue.
+	tmp, err := ioutil.TempFile(dir, filepath.Base(q.path)+"-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(buf.Bytes())
+	if sErr := tmp.Sync(); err == nil {
+		err = sErr
+	}
+
+	if cErr := tmp.Close(); err == nil {
+		err = cErr
+	}
+
+	if err == nil {
+		err = os.Chmod(tmp.Name(), fileMode)
+	}
+
+	if err == nil {
+		err = os.Rename(tmp.Name(), q.path)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
@@ -0,0 +1,140 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// Outcome is the outcome of replaying a single entry.
+type Outcome struct {
+	// Entry the entry which was replayed.
+	Entry *Entry
+	// Added the result of replaying a write which adds items, i.e to the history.
+	Added *trakt.AddToCollectionResult
+	// Removed the result of replaying a write which removes items.
+	Removed *trakt.RemoveFromCollectionResult
+	// Scrobble the result of replaying a stopped scrobble.
+	Scrobble *trakt.Scrobble
+	// Err the error trakt rejected the entry with, the entry is not retried. This is
+	// only set for a definitive rejection, such as a 4xx response.
+	Err error
+}
+
+// NotFound returns the items in the entry which trakt could not find, if any.
+func (o *Outcome) NotFound() *trakt.NotFound {
+	switch {
+	case o.Added != nil:
+		return o.Added.NotFound
+	case o.Removed != nil:
+		return o.Removed.NotFound
+	}
+
+	return nil
+}
+
+// replayParams the parameters used to replay an entry, the body is sent as it was queued.
+type replayParams struct {
+	trakt.Params
+	body json.RawMessage
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (r *replayParams) MarshalJSON() ([]byte, error) { return r.body, nil }
+
+// Replay sends the pending entries to trakt in the order they were queued using the supplied
+// client. An entry which trakt rejects, i.e with a 4xx response, is removed from the queue and its
+// error is reported in its outcome. If trakt cannot be reached, or the entry fails in a way which
+// may succeed later, such as a server error, a Cloudflare error or a timeout, replaying stops and
+// the error is returned along with the outcomes of the entries replayed so far. The failed entry
+// and the entries after it stay queued and are replayed next time.
+func (q *Queue) Replay(ctx context.Context, c trakt.BaseClient) ([]*Outcome, error) {
+	q.replay.Lock()
+	defer q.replay.Unlock()
+
+	var outcomes []*Outcome
+	for {
+		if err := ctx.Err(); err != nil {
+			return outcomes, err
+		}
+
+		e, ok := q.next()
+		if !ok {
+			return outcomes, nil
+		}
+
+		o := &Outcome{Entry: e}
+		var rcv interface{}
+		switch {
+		case strings.HasPrefix(e.Path, "/scrobble/"):
+			o.Scrobble = &trakt.Scrobble{}
+			rcv = o.Scrobble
+		case strings.HasSuffix(e.Path, "/remove"):
+			o.Removed = &trakt.RemoveFromCollectionResult{}
+			rcv = o.Removed
+		default:
+			o.Added = &trakt.AddToCollectionResult{}
+			rcv = o.Added
+		}
+
+		p := &replayParams{Params: trakt.Params{Context: ctx, OAuth: e.OAuth}, body: e.Body}
+		err := c.Call(e.Method, e.Path, p, rcv)
+		if isRetryable(err) || ctx.Err() != nil {
+			return outcomes, err
+		}
+
+		if err != nil {
+			o.Added, o.Removed, o.Scrobble, o.Err = nil, nil, nil, err
+		}
+
+		if err := q.done(e.ID); err != nil {
+			return outcomes, err
+		}
+
+		outcomes = append(outcomes, o)
+	}
+}
+
+// isRetryable determines if replaying an entry failed in a way which may succeed if it is replayed
+// later, in which case it is kept in the queue. This is the case when trakt could not be reached,
+// responded with a server error or rate limited the request, or the result of the write is unknown.
+func isRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return isUnreachable(err) ||
+		trakt.IsAmbiguousFailure(err) ||
+		errors.Is(err, trakt.ErrCircuitOpen) ||
+		errors.Is(err, trakt.ErrServerError) ||
+		errors.Is(err, trakt.ErrServerUnavailable) ||
+		errors.Is(err, trakt.ErrRateLimitExceeded)
+}
+
+// Run replays the pending entries every interval until the context is done, each outcome is
+// supplied to fn as it is replayed. Run returns once the context is done.
+func (q *Queue) Run(ctx context.Context, c trakt.BaseClient, interval time.Duration, fn func(*Outcome)) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		if q.Len() > 0 {
+			outcomes, _ := q.Replay(ctx, c)
+			for _, o := range outcomes {
+				if fn != nil {
+					fn(o)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
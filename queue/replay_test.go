@@ -0,0 +1,90 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/queue"
+	"github.com/jacklaaa89/trakt/sync"
+)
+
+// queueWrites queues n writes using a backend which cannot reach trakt.
+func queueWrites(t *testing.T, q *queue.Queue, n int) {
+	t.Helper()
+
+	offline := httptest.NewServer(http.NotFoundHandler())
+	offline.Close()
+
+	c := sync.NewClient(trakt.New("client-id", &trakt.BackendConfig{
+		URL:            offline.URL,
+		WriteRateLimit: trakt.NoRateLimit,
+		Middleware:     []trakt.Middleware{q.Middleware()},
+	}))
+
+	for i := 0; i < n; i++ {
+		_, err := c.AddToHistory(&trakt.AddToHistoryParams{
+			Params: trakt.Params{OAuth: "token"},
+			Movies: []*trakt.MediaHistoryParams{{IDs: trakt.MediaIDs{Trakt: trakt.ID(i + 1)}}},
+		})
+
+		if !errors.Is(err, queue.ErrQueued) {
+			t.Fatalf("expected %v, got %v", queue.ErrQueued, err)
+		}
+	}
+}
+
+func TestQueue_Replay(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		outcomes int
+		pending  int
+		want     error
+	}{
+		{name: "accepted", status: http.StatusCreated, outcomes: 2},
+		{name: "rejected", status: http.StatusUnprocessableEntity, outcomes: 2},
+		{name: "server error", status: http.StatusInternalServerError, pending: 2, want: trakt.ErrServerError},
+		{name: "cloudflare", status: 520, pending: 2, want: trakt.ErrServerUnavailable},
+		{name: "rate limited", status: http.StatusTooManyRequests, pending: 2, want: trakt.ErrRateLimitExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := queue.Open(filepath.Join(t.TempDir(), "trakt.queue"))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			defer q.Close()
+
+			queueWrites(t, q, 2)
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			c := trakt.New("client-id", &trakt.BackendConfig{
+				URL:            srv.URL,
+				WriteRateLimit: trakt.NoRateLimit,
+			})
+
+			outcomes, err := q.Replay(context.Background(), c)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+
+			if len(outcomes) != tt.outcomes || q.Len() != tt.pending {
+				t.Errorf(
+					"expected %d outcomes with %d pending, got %d with %d pending",
+					tt.outcomes, tt.pending, len(outcomes), q.Len(),
+				)
+			}
+		})
+	}
+}
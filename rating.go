@@ -28,3 +28,6 @@ func (r *RatingIterator) Rating() (*Rating, error) {
 	rcv := &Rating{}
 	return rcv, r.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (r *RatingIterator) Iter() *Iter[*Rating] { return newIter(r, r.Rating) }
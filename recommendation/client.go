@@ -10,8 +10,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a recommendation client.
-type client struct{ b trakt.BaseClient }
+// Client represents a recommendation client.
+type Client struct{ b trakt.PathClient }
 
 // Movies returns personalized movie recommendations for a user. By default, 10 results are returned.
 // You can send a limit to get up to 100 results per page. Set "IgnoreCollected" to true
@@ -29,8 +29,8 @@ func Movies(params *trakt.RecommendationListParams) *trakt.MovieIterator {
 //
 //  - OAuth Required
 //  - Extended Info
-func (c *client) Movies(params *trakt.RecommendationListParams) *trakt.MovieIterator {
-	return &trakt.MovieIterator{Iterator: c.b.NewIterator(http.MethodGet, "/recommendations/movies", params)}
+func (c *Client) Movies(params *trakt.RecommendationListParams) *trakt.MovieIterator {
+	return &trakt.MovieIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, trakt.NewPath("/recommendations/movies"), params)}
 }
 
 // Shows returns personalized show recommendations for a user. By default, 10 results are returned.
@@ -49,8 +49,8 @@ func Shows(params *trakt.RecommendationListParams) *trakt.ShowIterator {
 //
 //  - OAuth Required
 //  - Extended Info
-func (c *client) Shows(params *trakt.RecommendationListParams) *trakt.ShowIterator {
-	return &trakt.ShowIterator{Iterator: c.b.NewIterator(http.MethodGet, "/recommendations/shows", params)}
+func (c *Client) Shows(params *trakt.RecommendationListParams) *trakt.ShowIterator {
+	return &trakt.ShowIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, trakt.NewPath("/recommendations/shows"), params)}
 }
 
 // HideShow hides a show from getting recommended anymore.
@@ -63,8 +63,8 @@ func HideShow(id trakt.SearchID, params *trakt.Params) error {
 // HideShow hides a show from getting recommended anymore.
 //
 //  - OAuth Required
-func (c *client) HideShow(id trakt.SearchID, params *trakt.Params) error {
-	return c.b.Call(http.MethodDelete, trakt.FormatURLPath("/recommendations/shows/%s", id), params, nil)
+func (c *Client) HideShow(id trakt.SearchID, params *trakt.Params) error {
+	return c.b.CallPath(http.MethodDelete, trakt.NewPath("/recommendations/shows/%s", id), params, nil)
 }
 
 // HideMovie hides a movie from getting recommended anymore.
@@ -77,9 +77,13 @@ func HideMovie(id trakt.SearchID, params *trakt.Params) error {
 // HideMovie hides a movie from getting recommended anymore.
 //
 //  - OAuth Required
-func (c *client) HideMovie(id trakt.SearchID, params *trakt.Params) error {
-	return c.b.Call(http.MethodDelete, trakt.FormatURLPath("/recommendations/movies/%s", id), params, nil)
+func (c *Client) HideMovie(id trakt.SearchID, params *trakt.Params) error {
+	return c.b.CallPath(http.MethodDelete, trakt.NewPath("/recommendations/movies/%s", id), params, nil)
 }
 
+// NewClient initialises a new recommendation client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new recommendation client with the currently defined backend configuration.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
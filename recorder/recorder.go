@@ -0,0 +1,289 @@
+// Package recorder provides a HTTP transport which can record request / response pairs
+// made to trakt into a cassette file and replay them deterministically. This allows tests
+// for any package to run offline.
+//
+// A recorder plugs into the backend configuration using its HTTP client:
+//
+//  rec, err := recorder.New("testdata/shows", recorder.ModeReplay)
+//  if err != nil {
+//  	return err
+//  }
+//  defer rec.Stop()
+//
+//  trakt.WithConfig(&trakt.BackendConfig{HTTPClient: rec.Client()})
+//
+// OAuth tokens, authorization and device codes, client secrets and the trakt-api-key are
+// redacted before an interaction is stored, so cassettes are safe to commit.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode the mode the recorder operates in.
+type Mode int
+
+const (
+	// ModeReplay only replays interactions from the cassette, any request
+	// which has not been recorded results in an error.
+	ModeReplay Mode = iota
+	// ModeRecord performs every request against the real transport, recording
+	// the interactions into a new cassette.
+	ModeRecord
+	// ModeReplayOrRecord replays interactions from the cassette if they have been recorded,
+	// otherwise the request is performed against the real transport and recorded.
+	ModeReplayOrRecord
+)
+
+// cassetteExtension the file extension used for cassettes.
+const cassetteExtension = ".json"
+
+// ErrInteractionNotFound is returned when replaying a request which has not been recorded.
+var ErrInteractionNotFound = errors.New("recorder: interaction not found")
+
+// RecordedRequest is a request which has been stored in a cassette.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response which has been stored in a cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a single request / response pair.
+type Interaction struct {
+	Request  *RecordedRequest  `json:"request"`
+	Response *RecordedResponse `json:"response"`
+}
+
+// Cassette is the set of interactions stored in a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is a http.RoundTripper which records and replays interactions
+// using a cassette file.
+type Recorder struct {
+	sync.Mutex
+
+	// mode the mode the recorder is operating in.
+	mode Mode
+	// path the path to the cassette file.
+	path string
+	// transport the real transport used to perform requests when recording.
+	transport http.RoundTripper
+	// cassette the currently loaded cassette.
+	cassette *Cassette
+	// replayed tracks which interactions have already been replayed, so that
+	// identical requests are replayed in the order they were recorded.
+	replayed []bool
+	// modified whether new interactions have been recorded.
+	modified bool
+}
+
+// New initialises a new recorder using the cassette at path, the cassette extension is
+// appended if it is not supplied. The default HTTP transport is used when recording.
+func New(path string, mode Mode) (*Recorder, error) {
+	return NewWithTransport(path, mode, http.DefaultTransport)
+}
+
+// NewWithTransport initialises a new recorder using the cassette at path with the supplied
+// transport used to perform requests when recording.
+func NewWithTransport(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if !strings.HasSuffix(path, cassetteExtension) {
+		path += cassetteExtension
+	}
+
+	r := &Recorder{mode: mode, path: path, transport: transport, cassette: &Cassette{}}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	b, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err) && mode == ModeReplayOrRecord:
+		return r, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, r.cassette); err != nil {
+		return nil, err
+	}
+
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Client returns a HTTP client which uses the recorder as its transport.
+// This can be supplied as the HTTPClient on the backend configuration.
+func (r *Recorder) Client() *http.Client { return &http.Client{Transport: r} }
+
+// RoundTrip implements http.RoundTripper interface. The request is not modified, when
+// recording a clone of the request is performed against the real transport.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	rr, body, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode != ModeRecord {
+		if i, ok := r.find(rr); ok {
+			return i.Response.response(req), nil
+		}
+
+		if r.mode == ModeReplay {
+			return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, rr.Method, rr.URL)
+		}
+	}
+
+	res, err := r.transport.RoundTrip(cloneRequest(req, body))
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+	r.record(&Interaction{
+		Request: rr,
+		Response: &RecordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     redactHeader(res.Header),
+			Body:       redactBody(resBody, req.URL.Path),
+		},
+	})
+
+	return res, nil
+}
+
+// Stop saves the cassette if any new interactions have been recorded.
+func (r *Recorder) Stop() error {
+	r.Lock()
+	defer r.Unlock()
+
+	if !r.modified {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(r.path, b, 0644); err != nil {
+		return err
+	}
+
+	r.modified = false
+	return nil
+}
+
+// find finds the first interaction which matches the request and has not yet been replayed.
+func (r *Recorder) find(rr *RecordedRequest) (*Interaction, bool) {
+	r.Lock()
+	defer r.Unlock()
+
+	for idx, i := range r.cassette.Interactions {
+		if r.replayed[idx] || !i.Request.matches(rr) {
+			continue
+		}
+
+		r.replayed[idx] = true
+		return i, true
+	}
+
+	return nil, false
+}
+
+// record adds an interaction to the cassette.
+func (r *Recorder) record(i *Interaction) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.replayed = append(r.replayed, true)
+	r.modified = true
+}
+
+// matches determines if two requests are equal. Requests are matched on their
+// method, URL and body. Headers are not matched as they contain redacted values.
+func (r *RecordedRequest) matches(o *RecordedRequest) bool {
+	return r.Method == o.Method && r.URL == o.URL && r.Body == o.Body
+}
+
+// response generates a HTTP response for the supplied request from the recorded response.
+func (r *RecordedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// recordRequest generates a redacted copy of the request which can be stored or matched, along
+// with the request body. The body of the request is consumed and closed, as a transport would.
+func recordRequest(req *http.Request) (*RecordedRequest, []byte, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	u := *req.URL
+	u.RawQuery, u.ForceQuery = u.Query().Encode(), false
+
+	return &RecordedRequest{
+		Method: req.Method,
+		URL:    u.String(),
+		Header: redactHeader(req.Header),
+		Body:   redactBody(body, req.URL.Path),
+	}, body, nil
+}
+
+// cloneRequest clones the request so that it can be performed with the body which was consumed
+// from the original, the original request is not modified.
+func cloneRequest(req *http.Request, body []byte) *http.Request {
+	c := req.Clone(req.Context())
+	if req.Body == nil {
+		return c
+	}
+
+	c.Body = ioutil.NopCloser(bytes.NewReader(body))
+	c.GetBody = func() (io.ReadCloser, error) { return ioutil.NopCloser(bytes.NewReader(body)), nil }
+	return c
+}
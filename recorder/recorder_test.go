@@ -0,0 +1,90 @@
+package recorder
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RoundTrip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(b)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette")
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	body := ioutil.NopCloser(strings.NewReader(`{"movies":[]}`))
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/sync/history", body)
+	res, err := rec.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if req.Body != body {
+		t.Error("expected the body of the request not to be replaced")
+	}
+
+	if b, _ := ioutil.ReadAll(res.Body); string(b) != `{"movies":[]}` {
+		t.Errorf("expected the body to be sent, got %s", b)
+	}
+
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	replay, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	req, _ = http.NewRequest(http.MethodPost, srv.URL+"/sync/history", strings.NewReader(`{"movies":[]}`))
+	if _, err := replay.RoundTrip(req); err != nil {
+		t.Errorf("expected the interaction to be replayed, got %v", err)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		path string
+		body string
+		want string
+	}{
+		{
+			path: "/oauth/token",
+			body: `{"code":"abc","client_id":"id","client_secret":"secret"}`,
+			want: `{"client_id":"REDACTED","client_secret":"REDACTED","code":"REDACTED"}`,
+		},
+		{
+			path: "/oauth/device/code",
+			body: `{"device_code":"abc","user_code":"5055CC52","verification_url":"https://trakt.tv/activate"}`,
+			want: `{"device_code":"REDACTED","user_code":"5055CC52","verification_url":"https://trakt.tv/activate"}`,
+		},
+		{
+			path: "/oauth/device/token",
+			body: `{"code":"abc","access_token":"token","refresh_token":"refresh"}`,
+			want: `{"access_token":"REDACTED","code":"REDACTED","refresh_token":"REDACTED"}`,
+		},
+		{
+			path: "/countries/movies",
+			body: `[{"name":"Australia","code":"au"}]`,
+			want: `[{"name":"Australia","code":"au"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := redactBody([]byte(tt.body), tt.path); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
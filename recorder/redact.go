@@ -0,0 +1,112 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// redacted the value sensitive values are replaced with.
+const redacted = "REDACTED"
+
+// sensitiveHeaders the set of headers which are redacted.
+var sensitiveHeaders = []string{"Authorization", "trakt-api-key", "Cookie", "Set-Cookie"}
+
+// sensitiveFields the set of JSON fields which are redacted from request and response bodies.
+var sensitiveFields = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"client_secret": true,
+	"client_id":     true,
+	"token":         true,
+	"device_code":   true,
+}
+
+// oauthPath the prefix of the paths of the OAuth endpoints.
+const oauthPath = "/oauth/"
+
+// sensitiveOAuthFields the set of JSON fields which are also redacted from the bodies of requests
+// to the OAuth endpoints. The authorization code is only redacted from these as other endpoints use
+// code for values which are not sensitive, such as the code of a country or language.
+var sensitiveOAuthFields = map[string]bool{
+	"code": true,
+}
+
+// isSensitiveField determines if a field is redacted from the body of a request to path.
+func isSensitiveField(field, path string) bool {
+	return sensitiveFields[field] || (strings.HasPrefix(path, oauthPath) && sensitiveOAuthFields[field])
+}
+
+// redactHeader returns a copy of the headers with any sensitive values redacted.
+func redactHeader(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+
+	c := h.Clone()
+	for _, k := range sensitiveHeaders {
+		if c.Get(k) != "" {
+			c.Set(k, redacted)
+		}
+	}
+
+	return c
+}
+
+// redactBody redacts any sensitive fields from the JSON body of a request to path, or its response.
+// The body is returned untouched if it is not JSON or does not contain any sensitive fields.
+func redactBody(b []byte, path string) string {
+	if len(b) == 0 || !containsSensitiveField(b, path) {
+		return string(b)
+	}
+
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return string(b)
+	}
+
+	rb, err := json.Marshal(redactValue(v, path))
+	if err != nil {
+		return string(b)
+	}
+
+	return string(rb)
+}
+
+// containsSensitiveField performs a quick check to see if the body could contain a sensitive field.
+func containsSensitiveField(b []byte, path string) bool {
+	s := string(b)
+	for _, fields := range []map[string]bool{sensitiveFields, sensitiveOAuthFields} {
+		for f := range fields {
+			if strings.Contains(s, `"`+f+`"`) && isSensitiveField(f, path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// redactValue walks a decoded JSON value redacting any sensitive fields.
+func redactValue(v interface{}, path string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, f := range t {
+			if isSensitiveField(k, path) {
+				t[k] = redacted
+				continue
+			}
+
+			t[k] = redactValue(f, path)
+		}
+	case []interface{}:
+		for i, f := range t {
+			t[i] = redactValue(f, path)
+		}
+	}
+
+	return v
+}
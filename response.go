@@ -0,0 +1,56 @@
+package trakt
+
+import (
+	"net/http"
+	"time"
+)
+
+// ResponseMetadata contains the metadata of a response from trakt. It can be requested for any
+// call by supplying a receiver using the Response field on Params or BasicParams:
+//
+//  meta := &trakt.ResponseMetadata{}
+//  s, err := show.Get(trakt.Slug("the-office"), &trakt.ExtendedParams{
+//  	BasicParams: trakt.BasicParams{Response: meta},
+//  })
+//
+//  log.Println(meta.RequestID, meta.StatusCode, meta.Duration)
+//
+// The receiver is populated once the request has completed, regardless of whether the request
+// failed. If the parameters are used for multiple requests, i.e a verified write, it contains
+// the metadata of the last request.
+type ResponseMetadata struct {
+	// StatusCode the HTTP status code of the response, this is zero if the
+	// request failed to reach trakt.
+	StatusCode int
+	// Header the headers of the response, these include the rate limit
+	// and cache headers.
+	Header http.Header
+	// RequestID the request ID from the X-Request-ID header.
+	RequestID string
+	// Duration the total duration of the request, including any retries.
+	Duration time.Duration
+	// Retries the amount of retries which were performed.
+	Retries int
+	// Cached whether the response was served from the cache.
+	Cached bool
+	// Coalesced whether the response was shared with an identical in-flight request.
+	Coalesced bool
+}
+
+// RetryAfter how long trakt has asked us to wait before performing another request.
+func (r *ResponseMetadata) RetryAfter() time.Duration { return retryAfter(r.Header) }
+
+// populate populates the metadata from the response.
+func (r *ResponseMetadata) populate(res *http.Response, out *ResponseInfo) {
+	*r = ResponseMetadata{
+		StatusCode: out.StatusCode,
+		Duration:   out.Duration,
+		Retries:    out.Retries,
+		Cached:     out.Cached,
+		Coalesced:  out.Coalesced,
+	}
+
+	if res != nil {
+		r.Header, r.RequestID = res.Header.Clone(), res.Header.Get(requestIDHeader)
+	}
+}
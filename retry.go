@@ -0,0 +1,222 @@
+package trakt
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryAttempt contains the details of a failed attempt to perform a request
+// which a RetryPolicy uses to determine if the request should be retried.
+type RetryAttempt struct {
+	// Err the error from the attempt. This is either a *Error if trakt responded
+	// with an error status or the underlying network error.
+	Err error
+	// StatusCode the HTTP status code of the response, this is zero if
+	// the request failed to reach trakt.
+	StatusCode int
+	// Header the headers from the response, this is nil if the request
+	// failed to reach trakt.
+	Header http.Header
+	// Method the HTTP method of the request.
+	Method string
+	// Attempt the amount of retries which have already been performed, excluding
+	// retries performed after the request was rate limited.
+	Attempt int
+	// RateLimitAttempt the amount of retries which have already been performed after
+	// the request was rate limited.
+	RateLimitAttempt int
+}
+
+// RetryPolicy determines if a failed request should be retried and how long
+// to wait before performing the retry. Any waiting is aborted as soon as the
+// context for the request is done.
+type RetryPolicy interface {
+	// Retry returns whether the request should be retried and the amount of time
+	// to wait before the retry is performed.
+	Retry(a *RetryAttempt) (bool, time.Duration)
+}
+
+// DefaultRetryPolicy is the default RetryPolicy, it retries network errors and
+// intermittent server errors using an exponential backoff with jitter. Rate limited
+// requests are retried once the time trakt has told us to wait has elapsed.
+type DefaultRetryPolicy struct {
+	// MaxRetries is the maximum number of times that a request will be retried
+	// after a network error or an intermittent server error.
+	MaxRetries int
+	// MaxRateLimitRetries is the maximum number of times that a rate limited request
+	// will be retried, this is tracked separately from MaxRetries.
+	//
+	// Defaults to 3, a negative value disables retrying rate limited requests.
+	MaxRateLimitRetries int
+	// MinDelay is the minimum amount of time to wait between retries.
+	//
+	// Defaults to 500 milliseconds.
+	MinDelay time.Duration
+	// MaxDelay is the maximum amount of time to wait between retries.
+	//
+	// Defaults to 5 seconds.
+	MaxDelay time.Duration
+}
+
+// Retry implements RetryPolicy interface.
+func (d *DefaultRetryPolicy) Retry(a *RetryAttempt) (bool, time.Duration) {
+	// the limiter will wait for the rate limit to reset so there is no need to
+	// wait any further, if the method is not rate limited the backend waits instead.
+	if a.StatusCode == http.StatusTooManyRequests {
+		return d.shouldRetryRateLimited(a), 0
+	}
+
+	if !d.shouldRetry(a) {
+		return false, 0
+	}
+
+	return true, d.sleepTime(a.Attempt)
+}
+
+// shouldRetryRateLimited checks if a rate limited request should be retried.
+//
+// We only retry a rate limited request if trakt has told us how long
+// to wait, the limiter will then hold the retry until the rate limit
+// has been reset. Without this we would only contribute to more contention.
+func (d *DefaultRetryPolicy) shouldRetryRateLimited(a *RetryAttempt) bool {
+	max := d.MaxRateLimitRetries
+	if max == 0 {
+		max = defaultMaxRateLimitRetries
+	}
+
+	return a.RateLimitAttempt < max && retryAfter(a.Header) > 0
+}
+
+// Checks if an error is a problem that we should retry on. This includes both
+// socket errors that may represent an intermittent problem and some special
+// HTTP statuses.
+func (d *DefaultRetryPolicy) shouldRetry(a *RetryAttempt) bool {
+	if a.Attempt >= d.MaxRetries {
+		return false
+	}
+
+	if a.Err != nil && isNetworkError(a.Err) {
+		return true
+	}
+
+	// 409 Conflict
+	if a.StatusCode == http.StatusConflict {
+		return true
+	}
+
+	// 500 Internal Server Error
+	if a.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+
+	// 503 Service Unavailable
+	if a.StatusCode == http.StatusServiceUnavailable {
+		return true
+	}
+
+	// 503 Gateway Timeout
+	if a.StatusCode == http.StatusGatewayTimeout {
+		return true
+	}
+
+	// if we have a cloudflare error (which dont use standard HTTP status codes)
+	if isCloudflareError(a.StatusCode) {
+		return true
+	}
+
+	return false
+}
+
+// sleepTime calculates sleeping/delay time in milliseconds between failure and a new one request.
+func (d *DefaultRetryPolicy) sleepTime(numRetries int) time.Duration {
+	minDelay, maxDelay := d.MinDelay, d.MaxDelay
+	if minDelay <= 0 {
+		minDelay = minNetworkRetriesDelay
+	}
+
+	if maxDelay <= 0 {
+		maxDelay = maxNetworkRetriesDelay
+	}
+
+	// Apply exponential backoff with minDelay on the
+	// number of num_retries so far as inputs.
+	delay := minDelay + minDelay*time.Duration(numRetries*numRetries)
+
+	// Do not allow the number to exceed maxDelay.
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	// Apply some jitter by randomizing the value in the range of 75%-100%.
+	if jitter := int64(delay / 4); jitter > 0 {
+		delay -= time.Duration(rand.Int63n(jitter))
+	}
+
+	// But never sleep less than the base sleep seconds.
+	if delay < minDelay {
+		delay = minDelay
+	}
+
+	return delay
+}
+
+// RetryPolicyFor returns the RetryPolicy of the backend the supplied client performs its calls with.
+// nil is returned if the client was not generated by this package or uses a custom Backend.
+func RetryPolicyFor(c BaseClient) RetryPolicy {
+	var b *baseClient
+	switch v := c.(type) {
+	case *Client:
+		b = v.baseClient
+	case *baseClient:
+		b = v
+	case *pathClient:
+		return RetryPolicyFor(v.BaseClient)
+	}
+
+	if b == nil {
+		return nil
+	}
+
+	if s, ok := b.B.(*backendImplementation); ok {
+		return s.retryPolicy
+	}
+
+	return nil
+}
+
+// IsAmbiguousFailure determines if an error leaves it unknown whether a request was applied
+// by trakt, i.e the request failed to receive a response or trakt responded with a server error.
+// Retrying a write request after an ambiguous failure could cause it to be applied twice.
+func IsAmbiguousFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if isNetworkError(err) {
+		return true
+	}
+
+	var traktErr *Error
+	return errors.As(err, &traktErr) && traktErr.HTTPStatusCode >= http.StatusInternalServerError
+}
+
+// sleep waits for the supplied duration, returning early with the context
+// error if the context is done before the duration has elapsed.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,187 @@
+package trakt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDefaultRetryPolicy_Retry(t *testing.T) {
+	limited := http.Header{retryAfterHeader: []string{"1"}}
+
+	tests := []struct {
+		name    string
+		policy  *DefaultRetryPolicy
+		attempt *RetryAttempt
+		retry   bool
+	}{
+		{
+			name:    "rate limited without network retries",
+			policy:  &DefaultRetryPolicy{},
+			attempt: &RetryAttempt{StatusCode: http.StatusTooManyRequests, Header: limited},
+			retry:   true,
+		},
+		{
+			name:    "rate limited after network retries",
+			policy:  &DefaultRetryPolicy{MaxRetries: 1},
+			attempt: &RetryAttempt{StatusCode: http.StatusTooManyRequests, Header: limited, Attempt: 1},
+			retry:   true,
+		},
+		{
+			name:    "rate limited budget exhausted",
+			policy:  &DefaultRetryPolicy{},
+			attempt: &RetryAttempt{StatusCode: http.StatusTooManyRequests, Header: limited, RateLimitAttempt: 3},
+			retry:   false,
+		},
+		{
+			name:    "rate limited retries disabled",
+			policy:  &DefaultRetryPolicy{MaxRateLimitRetries: -1},
+			attempt: &RetryAttempt{StatusCode: http.StatusTooManyRequests, Header: limited},
+			retry:   false,
+		},
+		{
+			name:    "rate limited without retry after",
+			policy:  &DefaultRetryPolicy{},
+			attempt: &RetryAttempt{StatusCode: http.StatusTooManyRequests},
+			retry:   false,
+		},
+		{
+			name:    "server error without network retries",
+			policy:  &DefaultRetryPolicy{},
+			attempt: &RetryAttempt{StatusCode: http.StatusBadGateway},
+			retry:   false,
+		},
+		{
+			name:    "server error after rate limited retries",
+			policy:  &DefaultRetryPolicy{MaxRetries: 1},
+			attempt: &RetryAttempt{StatusCode: http.StatusBadGateway, RateLimitAttempt: 2},
+			retry:   true,
+		},
+		{
+			name:    "network error",
+			policy:  &DefaultRetryPolicy{MaxRetries: 1},
+			attempt: &RetryAttempt{Err: newNetworkError("/", errors.New("connection reset"))},
+			retry:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if retry, _ := tt.policy.Retry(tt.attempt); retry != tt.retry {
+				t.Errorf("expected retry to be %v, got %v", tt.retry, retry)
+			}
+		})
+	}
+}
+
+func TestBackend_RetryRateLimited(t *testing.T) {
+	const delay = 200 * time.Millisecond
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		limit   *RateLimit
+		hits    int32
+		err     error
+	}{
+		{name: "without a rate limit", hits: 2},
+		{name: "with a rate limit", limit: &RateLimit{Limit: 100, Period: time.Second}, hits: 2},
+		{name: "cancelled while waiting", timeout: delay / 4, hits: 1, err: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&hits, 1) == 1 {
+					until := time.Now().Add(delay).UTC().Format(time.RFC3339Nano)
+					w.Header().Set(rateLimitHeader, `{"name":"UNAUTHED_API_GET_LIMIT","until":"`+until+`"}`)
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"name":"ok"}`))
+			}))
+			defer srv.Close()
+
+			limit := tt.limit
+			if limit == nil {
+				limit = NoRateLimit
+			}
+
+			// the default config performs no network retries.
+			b := NewBackend(&BackendConfig{URL: srv.URL, GetRateLimit: limit})
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			var rcv struct {
+				Name string `json:"name"`
+			}
+
+			md := &ResponseMetadata{}
+			start := time.Now()
+			err := b.Call(http.MethodGet, "/movies/trending", "key", &BasicParams{Context: ctx, Response: md}, &rcv)
+			elapsed := time.Since(start)
+
+			if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if n := atomic.LoadInt32(&hits); n != tt.hits {
+				t.Errorf("expected %d requests, got %d", tt.hits, n)
+			}
+
+			if tt.err != nil {
+				if elapsed >= delay {
+					t.Errorf("expected waiting to stop once the context is done, waited %v", elapsed)
+				}
+
+				return
+			}
+
+			if elapsed < delay {
+				t.Errorf("expected to wait at least %v before retrying, waited %v", delay, elapsed)
+			}
+
+			if rcv.Name != "ok" {
+				t.Errorf("expected the response from the retried request, got %q", rcv.Name)
+			}
+
+			if md.Retries != 1 {
+				t.Errorf("expected 1 retry, got %d", md.Retries)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyFor(t *testing.T) {
+	policy := &DefaultRetryPolicy{MaxRetries: 2}
+	b := NewBackend(&BackendConfig{RetryPolicy: policy})
+
+	tests := []struct {
+		name   string
+		client BaseClient
+		policy RetryPolicy
+	}{
+		{name: "client", client: NewWithBackend("key", b), policy: policy},
+		{name: "paths", client: Paths(NewWithBackend("key", b)), policy: policy},
+		{name: "custom backend", client: NewWithBackend("key", struct{ Backend }{b})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if p := RetryPolicyFor(tt.client); p != tt.policy {
+				t.Errorf("expected %v, got %v", tt.policy, p)
+			}
+		})
+	}
+}
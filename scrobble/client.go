@@ -11,8 +11,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a client which can be used to perform scrobble requests.
-type client struct{ b trakt.BaseClient }
+// Client represents a client which can be used to perform scrobble requests.
+type Client struct{ b trakt.PathClient }
 
 // Start use this method when the video initially starts playing or is un-paused. This will remove
 // any playback progress if it exists.
@@ -32,9 +32,9 @@ func Start(params *trakt.ScrobbleParams) (*trakt.Scrobble, error) {
 // There is no need to call this method again while continuing to watch the same item.
 //
 //  - OAuth Required
-func (c *client) Start(params *trakt.ScrobbleParams) (*trakt.Scrobble, error) {
+func (c *Client) Start(params *trakt.ScrobbleParams) (*trakt.Scrobble, error) {
 	s := &trakt.Scrobble{}
-	err := c.b.Call(http.MethodPost, "/scrobble/start", params, s)
+	err := c.b.CallPath(http.MethodPost, trakt.NewPath("/scrobble/start"), params, s)
 	return s, err
 }
 
@@ -52,9 +52,9 @@ func Pause(params *trakt.ScrobbleParams) (*trakt.Scrobble, error) {
 // video in the exact position.
 //
 //  - OAuth Required
-func (c *client) Pause(params *trakt.ScrobbleParams) (*trakt.Scrobble, error) {
+func (c *Client) Pause(params *trakt.ScrobbleParams) (*trakt.Scrobble, error) {
 	s := &trakt.Scrobble{}
-	err := c.b.Call(http.MethodPost, "/scrobble/pause", params, s)
+	err := c.b.CallPath(http.MethodPost, trakt.NewPath("/scrobble/pause"), params, s)
 	return s, err
 }
 
@@ -88,11 +88,15 @@ func Stop(params *trakt.ScrobbleParams) (*trakt.Scrobble, error) {
 //
 // Note: If you prefer to use a threshold higher than 80%, you should use "Pause" yourself so
 // it doesn't create duplicate scrobbles.
-func (c *client) Stop(params *trakt.ScrobbleParams) (*trakt.Scrobble, error) {
+func (c *Client) Stop(params *trakt.ScrobbleParams) (*trakt.Scrobble, error) {
 	s := &trakt.Scrobble{}
-	err := c.b.Call(http.MethodPost, "/scrobble/stop", params, s)
+	err := c.b.CallPath(http.MethodPost, trakt.NewPath("/scrobble/stop"), params, s)
 	return s, err
 }
 
+// NewClient initialises a new scrobble client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new scrobble client with the currently defined backend.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
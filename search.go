@@ -107,3 +107,6 @@ func (s *SearchResultIterator) Result() (*SearchResult, error) {
 	rcv := &SearchResult{}
 	return rcv, s.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (s *SearchResultIterator) Iter() *Iter[*SearchResult] { return newIter(s, s.Result) }
@@ -13,8 +13,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a client which can be used to perform search requests.
-type client struct{ b trakt.BaseClient }
+// Client represents a client which can be used to perform search requests.
+type Client struct{ b trakt.PathClient }
 
 // wrappedSearchQuery this is only required because there seems to be
 // a weird bug with the "query" package in which it only runs the custom
@@ -79,9 +79,9 @@ func TextQuery(params *trakt.SearchQueryParams) *trakt.SearchResultIterator {
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) TextQuery(params *trakt.SearchQueryParams) *trakt.SearchResultIterator {
-	path := trakt.FormatURLPath("/search/%s", params.Type)
-	return &trakt.SearchResultIterator{Iterator: c.b.NewIterator(http.MethodGet, path, wrappedSearchQuery{params})}
+func (c *Client) TextQuery(params *trakt.SearchQueryParams) *trakt.SearchResultIterator {
+	path := trakt.NewPath("/search/%s", params.Type)
+	return &trakt.SearchResultIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, wrappedSearchQuery{params})}
 }
 
 // IDLookup attempts to lookup items by their Trakt, IMDB, TMDB, or TVDB ID. If you use the search url
@@ -100,16 +100,16 @@ func IDLookup(id trakt.SearchID, params *trakt.IDLookupParams) *trakt.SearchResu
 //
 //  - Pagination
 //  - Extended Info
-func (c *client) IDLookup(id trakt.SearchID, params *trakt.IDLookupParams) *trakt.SearchResultIterator {
-	path := trakt.FormatURLPath(trakt.IDPath(id), id)
+func (c *Client) IDLookup(id trakt.SearchID, params *trakt.IDLookupParams) *trakt.SearchResultIterator {
+	path := trakt.NewPath(trakt.IDPath(id), id)
 	return &trakt.SearchResultIterator{
-		Iterator: c.b.NewIteratorWithCondition(http.MethodGet, path, params, func() error {
+		Iterator: c.b.NewIteratorWithConditionPath(http.MethodGet, path, params, func() error {
 			switch id.(type) {
 			case trakt.Slug, *trakt.Slug:
 				return &trakt.Error{
 					HTTPStatusCode: http.StatusUnprocessableEntity,
 					Body:           "invalid type supplied for ID lookup, only IMDB|ID|TVDB|TMDB allowed",
-					Resource:       path,
+					Resource:       path.Path,
 					Code:           trakt.ErrorCodeValidationError,
 				}
 			}
@@ -118,5 +118,9 @@ func (c *client) IDLookup(id trakt.SearchID, params *trakt.IDLookupParams) *trak
 	}
 }
 
+// NewClient initialises a new search client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new search client with the currently defined backend.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -23,6 +23,9 @@ func (s *SeasonIterator) Season() (*Season, error) {
 	return rcv, s.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (s *SeasonIterator) Iter() *Iter[*Season] { return newIter(s, s.Season) }
+
 type SeasonWithEpisodes struct {
 	Season
 	Episodes []*Episode `json:"episodes"`
@@ -34,3 +37,6 @@ func (s *SeasonWithEpisodesIterator) Season() (*SeasonWithEpisodes, error) {
 	rcv := &SeasonWithEpisodes{}
 	return rcv, s.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (s *SeasonWithEpisodesIterator) Iter() *Iter[*SeasonWithEpisodes] { return newIter(s, s.Season) }
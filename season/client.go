@@ -7,8 +7,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a season client.
-type client struct{ b trakt.BaseClient }
+// Client represents a season client.
+type Client struct{ b trakt.PathClient }
 
 // Episodes returns all episodes for a specific season of a show.
 //
@@ -34,15 +34,15 @@ func Episodes(id trakt.SearchID, season int64, p *trakt.EpisodeListParams) *trak
 // Note: This returns a lot of data, so please only use this parameter if you actually need it!
 //
 //  - Extended Info
-func (c *client) Episodes(
+func (c *Client) Episodes(
 	id trakt.SearchID,
 	season int64,
 	params *trakt.EpisodeListParams,
 ) *trakt.EpisodeWithTranslationsIterator {
 
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s", id, season)
+	path := trakt.NewPath("/shows/%s/seasons/%s", id, season)
 	return &trakt.EpisodeWithTranslationsIterator{
-		BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params),
+		BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params),
 	}
 }
 
@@ -60,9 +60,9 @@ func Comments(id trakt.SearchID, season int64, params *trakt.CommentListParams)
 // and highest watched percentage.
 //
 //  - Pagination
-func (c *client) Comments(id trakt.SearchID, season int64, params *trakt.CommentListParams) *trakt.CommentIterator {
-	path := trakt.FormatURLPath("shows/%s/seasons/%s/comments/%s", id, season, params.Sort)
-	return &trakt.CommentIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Comments(id trakt.SearchID, season int64, params *trakt.CommentListParams) *trakt.CommentIterator {
+	path := trakt.NewPath("shows/%s/seasons/%s/comments/%s", id, season, params.Sort)
+	return &trakt.CommentIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Lists returns all lists that contain this season. By default, personal lists are returned sorted
@@ -77,11 +77,11 @@ func Lists(id trakt.SearchID, season int64, params *trakt.GetListParams) *trakt.
 // by the most popular.
 //
 //  - Pagination
-func (c *client) Lists(id trakt.SearchID, season int64, params *trakt.GetListParams) *trakt.ListIterator {
-	path := trakt.FormatURLPath(
+func (c *Client) Lists(id trakt.SearchID, season int64, params *trakt.GetListParams) *trakt.ListIterator {
+	path := trakt.NewPath(
 		"/shows/%s/seasons/%s/lists/%s/%s", id, season, params.ListType, params.SortType,
 	)
-	return &trakt.ListIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+	return &trakt.ListIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // People returns all cast and crew for an season.
@@ -120,10 +120,10 @@ func People(id trakt.SearchID, season int64, params *trakt.ExtendedParams) (*tra
 // Note: This returns a lot of data, so please only use this extended parameter if you actually need it!
 //
 //  - Extended Info
-func (c *client) People(id trakt.SearchID, season int64, params *trakt.ExtendedParams) (*trakt.CastAndCrew, error) {
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s/people", id, season)
+func (c *Client) People(id trakt.SearchID, season int64, params *trakt.ExtendedParams) (*trakt.CastAndCrew, error) {
+	path := trakt.NewPath("/shows/%s/seasons/%s/people", id, season)
 	cc := &trakt.CastAndCrew{}
-	err := c.b.Call(http.MethodGet, path, params, cc)
+	err := c.b.CallPath(http.MethodGet, path, params, cc)
 	return cc, err
 }
 
@@ -133,10 +133,10 @@ func Ratings(id trakt.SearchID, season int64, params *trakt.BasicParams) (*trakt
 }
 
 // Ratings returns the rating (between 0 and 10) and distribution for a season.
-func (c *client) Ratings(id trakt.SearchID, season int64, p *trakt.BasicParams) (*trakt.RatingDistribution, error) {
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s/ratings", id, season)
+func (c *Client) Ratings(id trakt.SearchID, season int64, p *trakt.BasicParams) (*trakt.RatingDistribution, error) {
+	path := trakt.NewPath("/shows/%s/seasons/%s/ratings", id, season)
 	r := &trakt.RatingDistribution{}
-	err := c.b.Call(http.MethodGet, path, p, r)
+	err := c.b.CallPath(http.MethodGet, path, p, r)
 	return r, err
 }
 
@@ -146,10 +146,10 @@ func Statistics(id trakt.SearchID, season int64, params *trakt.BasicParams) (*tr
 }
 
 // Statistics returns lots of season stats.
-func (c *client) Statistics(id trakt.SearchID, season int64, params *trakt.BasicParams) (*trakt.Statistics, error) {
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s/stats", id, season)
+func (c *Client) Statistics(id trakt.SearchID, season int64, params *trakt.BasicParams) (*trakt.Statistics, error) {
+	path := trakt.NewPath("/shows/%s/seasons/%s/stats", id, season)
 	stats := &trakt.Statistics{}
-	err := c.b.Call(http.MethodGet, path, params, stats)
+	err := c.b.CallPath(http.MethodGet, path, params, stats)
 	return stats, err
 }
 
@@ -163,10 +163,14 @@ func WatchingNow(id trakt.SearchID, season int64, params *trakt.BasicListParams)
 // WatchingNow returns all users watching this season right now.
 //
 //  - Extended Info
-func (c *client) WatchingNow(id trakt.SearchID, season int64, params *trakt.BasicListParams) *trakt.UserIterator {
-	path := trakt.FormatURLPath("/shows/%s/seasons/%s/watching", id, season)
-	return &trakt.UserIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) WatchingNow(id trakt.SearchID, season int64, params *trakt.BasicListParams) *trakt.UserIterator {
+	path := trakt.NewPath("/shows/%s/seasons/%s/watching", id, season)
+	return &trakt.UserIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
+// NewClient initialises a new season client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new season client with the currently defined backend configuration.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
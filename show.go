@@ -56,6 +56,9 @@ func (s *ShowIterator) Show() (*Show, error) {
 	return rcv, s.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (s *ShowIterator) Iter() *Iter[*Show] { return newIter(s, s.Show) }
+
 type TrendingShow struct {
 	Show     `json:"show"`
 	Watchers int64 `json:"watchers"`
@@ -68,6 +71,9 @@ func (t *TrendingShowIterator) Trending() (*TrendingShow, error) {
 	return rcv, t.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (t *TrendingShowIterator) Iter() *Iter[*TrendingShow] { return newIter(t, t.Trending) }
+
 type RecentlyUpdatedShow struct {
 	Show      `json:"show"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -80,6 +86,9 @@ func (r *RecentlyUpdatedShowIterator) Show() (*RecentlyUpdatedShow, error) {
 	return rcv, r.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (r *RecentlyUpdatedShowIterator) Iter() *Iter[*RecentlyUpdatedShow] { return newIter(r, r.Show) }
+
 type ShowWithStatistics struct {
 	statistics
 	Show `json:"show"`
@@ -92,6 +101,9 @@ func (s *ShowWithStatisticsIterator) Show() (*ShowWithStatistics, error) {
 	return rcv, s.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (s *ShowWithStatisticsIterator) Iter() *Iter[*ShowWithStatistics] { return newIter(s, s.Show) }
+
 type AnticipatedShow struct {
 	Show      `json:"show"`
 	ListCount int64 `json:"list_count"`
@@ -103,3 +115,6 @@ func (a *AnticipatedShowIterator) Show() (*AnticipatedShow, error) {
 	rcv := &AnticipatedShow{}
 	return rcv, a.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (a *AnticipatedShowIterator) Iter() *Iter[*AnticipatedShow] { return newIter(a, a.Show) }
@@ -7,8 +7,8 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a show client which can retrieve details about shows.
-type client struct{ b trakt.BaseClient }
+// Client represents a show client which can retrieve details about shows.
+type Client struct{ b trakt.PathClient }
 
 // Trending returns all shows being watched right now. Shows with the most users are returned first.
 //
@@ -24,8 +24,8 @@ func Trending(params *trakt.FilterListParams) *trakt.TrendingShowIterator {
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Trending(params *trakt.FilterListParams) *trakt.TrendingShowIterator {
-	return &trakt.TrendingShowIterator{Iterator: c.b.NewIterator(http.MethodGet, "/shows/trending", params)}
+func (c *Client) Trending(params *trakt.FilterListParams) *trakt.TrendingShowIterator {
+	return &trakt.TrendingShowIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, trakt.NewPath("/shows/trending"), params)}
 }
 
 // Popular returns the most popular shows. Popularity is calculated using the rating percentage and
@@ -44,8 +44,8 @@ func Popular(params *trakt.FilterListParams) *trakt.ShowIterator {
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Popular(params *trakt.FilterListParams) *trakt.ShowIterator {
-	return &trakt.ShowIterator{Iterator: c.b.NewIterator(http.MethodGet, "/shows/popular", params)}
+func (c *Client) Popular(params *trakt.FilterListParams) *trakt.ShowIterator {
+	return &trakt.ShowIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, trakt.NewPath("/shows/popular"), params)}
 }
 
 // Played returns the most played (a single user can watch multiple episodes multiple times) shows in the
@@ -64,7 +64,7 @@ func Played(params *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterato
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Played(params *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterator {
+func (c *Client) Played(params *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterator {
 	return c.newTimePeriodIterator("played", params)
 }
 
@@ -84,7 +84,7 @@ func Watched(params *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterat
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Watched(params *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterator {
+func (c *Client) Watched(params *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterator {
 	return c.newTimePeriodIterator("watched", params)
 }
 
@@ -104,7 +104,7 @@ func Collected(params *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIter
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Collected(params *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterator {
+func (c *Client) Collected(params *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterator {
 	return c.newTimePeriodIterator("collected", params)
 }
 
@@ -122,8 +122,8 @@ func Anticipated(params *trakt.FilterListParams) *trakt.AnticipatedShowIterator
 //  - Pagination
 //  - Filters
 //  - Extended Info
-func (c *client) Anticipated(params *trakt.FilterListParams) *trakt.AnticipatedShowIterator {
-	return &trakt.AnticipatedShowIterator{Iterator: c.b.NewIterator(http.MethodGet, "/shows/anticipated", params)}
+func (c *Client) Anticipated(params *trakt.FilterListParams) *trakt.AnticipatedShowIterator {
+	return &trakt.AnticipatedShowIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, trakt.NewPath("/shows/anticipated"), params)}
 }
 
 // RecentlyUpdated Returns all shows updated since the specified UTC date. We recommended storing the date
@@ -142,9 +142,9 @@ func RecentlyUpdated(params *trakt.RecentlyUpdatedListParams) *trakt.RecentlyUpd
 //
 //  - Pagination
 //  - Extended Info
-func (c *client) RecentlyUpdated(params *trakt.RecentlyUpdatedListParams) *trakt.RecentlyUpdatedShowIterator {
-	path := trakt.FormatURLPath("/shows/updates/%s", params.StartDate.Format(`2006-01-02`))
-	return &trakt.RecentlyUpdatedShowIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) RecentlyUpdated(params *trakt.RecentlyUpdatedListParams) *trakt.RecentlyUpdatedShowIterator {
+	path := trakt.NewPath("/shows/updates/%s", params.StartDate.Format(`2006-01-02`))
+	return &trakt.RecentlyUpdatedShowIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Get returns a single shows's details. If you request extended info, the airs object is relative to
@@ -161,10 +161,10 @@ func Get(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Show, error) {
 // it to whatever timezone your user is in.
 //
 //  - Extended Info
-func (c *client) Get(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Show, error) {
-	path := trakt.FormatURLPath("/shows/%s", id)
+func (c *Client) Get(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Show, error) {
+	path := trakt.NewPath("/shows/%s", id)
 	mov := &trakt.Show{}
-	err := c.b.Call(http.MethodGet, path, params, mov)
+	err := c.b.CallPath(http.MethodGet, path, params, mov)
 	return mov, err
 }
 
@@ -174,9 +174,9 @@ func Aliases(id trakt.SearchID, params *trakt.BasicParams) *trakt.AliasIterator
 }
 
 // Aliases returns all title aliases for a show. Includes country where name is different.
-func (c *client) Aliases(id trakt.SearchID, params *trakt.BasicParams) *trakt.AliasIterator {
-	path := trakt.FormatURLPath("shows/%s/aliases", id)
-	return &trakt.AliasIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+func (c *Client) Aliases(id trakt.SearchID, params *trakt.BasicParams) *trakt.AliasIterator {
+	path := trakt.NewPath("shows/%s/aliases", id)
+	return &trakt.AliasIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
 // Certifications returns all content certifications for a show, including the country.
@@ -185,9 +185,9 @@ func Certifications(id trakt.SearchID, params *trakt.BasicParams) *trakt.Certifi
 }
 
 // Certifications returns all content certifications for a show, including the country.
-func (c *client) Certifications(id trakt.SearchID, params *trakt.BasicParams) *trakt.CertificationIterator {
-	path := trakt.FormatURLPath("shows/%s/certifications", id)
-	return &trakt.CertificationIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+func (c *Client) Certifications(id trakt.SearchID, params *trakt.BasicParams) *trakt.CertificationIterator {
+	path := trakt.NewPath("shows/%s/certifications", id)
+	return &trakt.CertificationIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
 // Translations returns all translations for a show, including language and translated values for title and overview.
@@ -196,9 +196,9 @@ func Translations(id trakt.SearchID, params *trakt.TranslationListParams) *trakt
 }
 
 // Translations returns all translations for a show, including language and translated values for title and overview.
-func (c *client) Translations(id trakt.SearchID, params *trakt.TranslationListParams) *trakt.TranslationIterator {
-	path := trakt.FormatURLPath("shows/%s/translations/%s", id, params.Language)
-	return &trakt.TranslationIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+func (c *Client) Translations(id trakt.SearchID, params *trakt.TranslationListParams) *trakt.TranslationIterator {
+	path := trakt.NewPath("shows/%s/translations/%s", id, params.Language)
+	return &trakt.TranslationIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
 // Comments returns all top level comments for a show. By default, the newest comments are returned first.
@@ -215,9 +215,9 @@ func Comments(id trakt.SearchID, params *trakt.CommentListParams) *trakt.Comment
 // and highest watched percentage.
 //
 //  - Pagination
-func (c *client) Comments(id trakt.SearchID, params *trakt.CommentListParams) *trakt.CommentIterator {
-	path := trakt.FormatURLPath("shows/%s/comments/%s", id, params.Sort)
-	return &trakt.CommentIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Comments(id trakt.SearchID, params *trakt.CommentListParams) *trakt.CommentIterator {
+	path := trakt.NewPath("shows/%s/comments/%s", id, params.Sort)
+	return &trakt.CommentIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Lists Returns all lists that contain this show. By default, personal lists are returned sorted by the
@@ -232,9 +232,9 @@ func Lists(id trakt.SearchID, params *trakt.GetListParams) *trakt.ListIterator {
 // most popular.
 //
 //  - Pagination
-func (c *client) Lists(id trakt.SearchID, params *trakt.GetListParams) *trakt.ListIterator {
-	path := trakt.FormatURLPath("shows/%s/lists/%s/%s", id, params.ListType, params.SortType)
-	return &trakt.ListIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Lists(id trakt.SearchID, params *trakt.GetListParams) *trakt.ListIterator {
+	path := trakt.NewPath("shows/%s/lists/%s/%s", id, params.ListType, params.SortType)
+	return &trakt.ListIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // CollectionProgress returns collection progress for a show including details on all aired seasons and episodes.
@@ -279,10 +279,10 @@ func CollectionProgress(id trakt.SearchID, params *trakt.ProgressParams) (*trakt
 // date are ignored.
 //
 //  - OAuth Required
-func (c *client) CollectionProgress(id trakt.SearchID, params *trakt.ProgressParams) (*trakt.CollectedProgress, error) {
-	path := trakt.FormatURLPath("/shows/%s/progress/collection", id)
+func (c *Client) CollectionProgress(id trakt.SearchID, params *trakt.ProgressParams) (*trakt.CollectedProgress, error) {
+	path := trakt.NewPath("/shows/%s/progress/collection", id)
 	cc := &trakt.CollectedProgress{}
-	err := c.b.Call(http.MethodGet, path, params, cc)
+	err := c.b.CallPath(http.MethodGet, path, params, cc)
 	return cc, err
 }
 
@@ -330,10 +330,10 @@ func WatchedProgress(id trakt.SearchID, params *trakt.ProgressParams) (*trakt.Wa
 // date are ignored.
 //
 //  - OAuth Required
-func (c *client) WatchedProgress(id trakt.SearchID, params *trakt.ProgressParams) (*trakt.WatchedProgress, error) {
-	path := trakt.FormatURLPath("/shows/%s/progress/watched", id)
+func (c *Client) WatchedProgress(id trakt.SearchID, params *trakt.ProgressParams) (*trakt.WatchedProgress, error) {
+	path := trakt.NewPath("/shows/%s/progress/watched", id)
 	cc := &trakt.WatchedProgress{}
-	err := c.b.Call(http.MethodGet, path, params, cc)
+	err := c.b.CallPath(http.MethodGet, path, params, cc)
 	return cc, err
 }
 
@@ -373,10 +373,10 @@ func People(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.CastAndCrew
 // Note: This returns a lot of data, so please only use this extended parameter if you actually need it!
 //
 //  - Extended Info
-func (c *client) People(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.CastAndCrew, error) {
-	path := trakt.FormatURLPath("/shows/%s/people", id)
+func (c *Client) People(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.CastAndCrew, error) {
+	path := trakt.NewPath("/shows/%s/people", id)
 	cc := &trakt.CastAndCrew{}
-	err := c.b.Call(http.MethodGet, path, params, cc)
+	err := c.b.CallPath(http.MethodGet, path, params, cc)
 	return cc, err
 }
 
@@ -386,10 +386,10 @@ func Ratings(id trakt.SearchID, params *trakt.BasicParams) (*trakt.RatingDistrib
 }
 
 // Ratings returns the rating (between 0 and 10) and distribution for a show.
-func (c *client) Ratings(id trakt.SearchID, params *trakt.BasicParams) (*trakt.RatingDistribution, error) {
-	path := trakt.FormatURLPath("/shows/%s/ratings", id)
+func (c *Client) Ratings(id trakt.SearchID, params *trakt.BasicParams) (*trakt.RatingDistribution, error) {
+	path := trakt.NewPath("/shows/%s/ratings", id)
 	stats := &trakt.RatingDistribution{}
-	err := c.b.Call(http.MethodGet, path, params, stats)
+	err := c.b.CallPath(http.MethodGet, path, params, stats)
 	return stats, err
 }
 
@@ -405,9 +405,9 @@ func Related(id trakt.SearchID, params *trakt.ExtendedListParams) *trakt.ShowIte
 //
 //  - Pagination
 //  - Extended Info
-func (c *client) Related(id trakt.SearchID, params *trakt.ExtendedListParams) *trakt.ShowIterator {
-	path := trakt.FormatURLPath("shows/%s/related", id)
-	return &trakt.ShowIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Related(id trakt.SearchID, params *trakt.ExtendedListParams) *trakt.ShowIterator {
+	path := trakt.NewPath("shows/%s/related", id)
+	return &trakt.ShowIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // Statistics returns lots of show stats.
@@ -416,10 +416,10 @@ func Statistics(id trakt.SearchID, params *trakt.BasicParams) (*trakt.Statistics
 }
 
 // Statistics returns lots of show stats.
-func (c *client) Statistics(id trakt.SearchID, params *trakt.BasicParams) (*trakt.Statistics, error) {
-	path := trakt.FormatURLPath("/shows/%s/stats", id)
+func (c *Client) Statistics(id trakt.SearchID, params *trakt.BasicParams) (*trakt.Statistics, error) {
+	path := trakt.NewPath("/shows/%s/stats", id)
 	stats := &trakt.Statistics{}
-	err := c.b.Call(http.MethodGet, path, params, stats)
+	err := c.b.CallPath(http.MethodGet, path, params, stats)
 	return stats, err
 }
 
@@ -433,9 +433,9 @@ func WatchingNow(id trakt.SearchID, params *trakt.BasicListParams) *trakt.UserIt
 // WatchingNow returns all users watching this show right now.
 //
 //  - Extended Info
-func (c *client) WatchingNow(id trakt.SearchID, params *trakt.BasicListParams) *trakt.UserIterator {
-	path := trakt.FormatURLPath("shows/%s/watching", id)
-	return &trakt.UserIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) WatchingNow(id trakt.SearchID, params *trakt.BasicListParams) *trakt.UserIterator {
+	path := trakt.NewPath("shows/%s/watching", id)
+	return &trakt.UserIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // NextEpisode returns the next scheduled to air episode. If no episode is found,
@@ -450,10 +450,10 @@ func NextEpisode(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Episod
 // no error will be returned, but the episode will also be nil.
 //
 //  - Extended Info
-func (c *client) NextEpisode(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Episode, error) {
+func (c *Client) NextEpisode(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Episode, error) {
 	ep := &trakt.Episode{}
-	path := trakt.FormatURLPath("shows/%s/next_episode", id)
-	err := c.b.Call(http.MethodGet, path, params, ep)
+	path := trakt.NewPath("shows/%s/next_episode", id)
+	err := c.b.CallPath(http.MethodGet, path, params, ep)
 	return handleNoEpisodeFound(ep, err)
 }
 
@@ -469,10 +469,10 @@ func LastEpisode(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Episod
 // no error will be returned, but the episode will also be nil.
 //
 //  - Extended Info
-func (c *client) LastEpisode(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Episode, error) {
+func (c *Client) LastEpisode(id trakt.SearchID, params *trakt.ExtendedParams) (*trakt.Episode, error) {
 	ep := &trakt.Episode{}
-	path := trakt.FormatURLPath("shows/%s/last_episode", id)
-	err := c.b.Call(http.MethodGet, path, params, ep)
+	path := trakt.NewPath("shows/%s/last_episode", id)
+	err := c.b.CallPath(http.MethodGet, path, params, ep)
 	return handleNoEpisodeFound(ep, err)
 }
 
@@ -498,20 +498,20 @@ func Seasons(id trakt.SearchID, params *trakt.ExtendedListParams) *trakt.SeasonW
 // Note: This returns a lot of data, so please only use this extended parameter if you actually need it!
 //
 //  - Extended Info
-func (c *client) Seasons(id trakt.SearchID, params *trakt.ExtendedListParams) *trakt.SeasonWithEpisodesIterator {
-	path := trakt.FormatURLPath("shows/%s/seasons", id)
-	return &trakt.SeasonWithEpisodesIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+func (c *Client) Seasons(id trakt.SearchID, params *trakt.ExtendedListParams) *trakt.SeasonWithEpisodesIterator {
+	path := trakt.NewPath("shows/%s/seasons", id)
+	return &trakt.SeasonWithEpisodesIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
 // newTimePeriodIterator generates a new show iterator with the defined action and params.
 // it will set the default time period to weekly if not supplied, this emulates the APIs default.
-func (c *client) newTimePeriodIterator(action string, p *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterator {
+func (c *Client) newTimePeriodIterator(action string, p *trakt.TimePeriodListParams) *trakt.ShowWithStatisticsIterator {
 	var period = trakt.TimePeriodWeekly
 	if p.Period != "" {
 		period = p.Period
 	}
-	path := trakt.FormatURLPath("/shows/%s/%s", action, period)
-	return &trakt.ShowWithStatisticsIterator{Iterator: c.b.NewIterator(http.MethodGet, path, p)}
+	path := trakt.NewPath("/shows/%s/%s", action, period)
+	return &trakt.ShowWithStatisticsIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, p)}
 }
 
 // handleNoEpisodeFound helper function to handle if the response was a success but no episode was found
@@ -534,5 +534,9 @@ func handleNoEpisodeFound(e *trakt.Episode, err error) (*trakt.Episode, error) {
 	return e, nil
 }
 
+// NewClient initialises a new show client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new show client with the current backend configuration.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -3,10 +3,104 @@ package show
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
 
 	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/recorder"
+	"github.com/jacklaaa89/trakt/trakttest"
 )
 
+// cassette the cassette the interactions performed by the examples are recorded into.
+const cassette = "testdata/examples.json"
+
+// recorded whether the cassette is used to replay, or record, the interactions performed by the
+// examples. The tests which run the examples are skipped if there is no cassette to replay.
+var recorded bool
+
+func TestMain(m *testing.M) {
+	// replay the recorded interactions so the examples can run offline, set TRAKT_RECORD
+	// along with TRAKT_CLIENT_ID to record them against the live API.
+	mode, transport := recorder.ModeReplay, http.DefaultTransport
+	if os.Getenv("TRAKT_RECORD") != "" {
+		key := os.Getenv("TRAKT_CLIENT_ID")
+		if key == "" {
+			fmt.Println("TRAKT_CLIENT_ID is required to record the examples")
+			os.Exit(1)
+		}
+
+		mode, transport = recorder.ModeRecord, &keyTransport{key: key, next: transport}
+	} else if _, err := os.Stat(cassette); os.IsNotExist(err) {
+		os.Exit(m.Run())
+	}
+
+	rec, err := recorder.NewWithTransport(cassette, mode, transport)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	recorded = true
+	trakt.WithConfig(&trakt.BackendConfig{HTTPClient: rec.Client()})
+
+	code := m.Run()
+	if err := rec.Stop(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	os.Exit(code)
+}
+
+// runExample runs an example which performs requests against the API, returning the lines it
+// printed. The test is skipped if there is no cassette to replay the requests from.
+func runExample(t *testing.T, example func()) []string {
+	t.Helper()
+
+	if !recorded {
+		t.Skip("no cassette has been recorded, set TRAKT_RECORD and TRAKT_CLIENT_ID to record one")
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	out := make(chan []byte)
+	go func() {
+		b, _ := ioutil.ReadAll(r)
+		out <- b
+	}()
+
+	example()
+	os.Stdout = stdout
+	_ = w.Close()
+
+	return strings.Split(strings.TrimSpace(string(<-out)), "\n")
+}
+
+// keyTransport performs requests using the client_id supplied in the environment, as the
+// examples use a placeholder for the key.
+type keyTransport struct {
+	key  string
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (k *keyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c := req.Clone(req.Context())
+	c.Header.Set("trakt-api-key", k.key)
+	return k.next.RoundTrip(c)
+}
+
+// The examples perform requests against the API, so rather than declaring their output, which
+// would fail whenever there is no cassette, their output is checked by the TestExample tests.
 func ExampleRelated() {
 	trakt.Key = "<client_id>"
 
@@ -25,17 +119,6 @@ func ExampleRelated() {
 		}
 		fmt.Printf("%v (%v)\n", s.Title, s.Year)
 	}
-	// Output:
-	// One-Punch Man (2015)
-	// Dragon Ball Z (1989)
-	// Naruto (2002)
-	// Dragon Ball (1986)
-	// Steins;Gate (2011)
-	// My Hero Academia (2016)
-	// Fairy Tail (2009)
-	// Dragon Ball Super (2015)
-	// Samurai Champloo (2004)
-	// Dragon Ball GT (1996)
 }
 
 func ExampleSeasons() {
@@ -73,22 +156,75 @@ func ExampleSeasons() {
 		}
 		fmt.Println("========================")
 	}
-	// Output:
-	// One Piece - (1999)
-	// ========================
-	// Specials
-	// ========================
-	// 1 - One Piece: Defeat the Pirate Ganzack! (OVA 1)
-	// 2 - One Piece: The Movie
-	// 3 - Adventure in the Ocean's Navel
-	// 4 - Clockwork Island Adventure
-	// 5 - Jango's Dance Carnival
-	// 6 - Chopper's Kingdom on the Island of Strange Animals
-	// 7 - Soccer King of Dreams
-	// 8 - Dead End Adventure
-	// 9 - Open Upon the Great Sea! A Father's Huge, HUGE Dream!
-	// 10 - Protect! The Last Great Performance
-	// 11 - Curse of the Sacred Sword
-	// 12 - Baseball King
-	// ...
+}
+
+func TestExampleRelated(t *testing.T) {
+	want := []string{
+		"One-Punch Man (2015)",
+		"Dragon Ball Z (1989)",
+		"Naruto (2002)",
+		"Dragon Ball (1986)",
+		"Steins;Gate (2011)",
+		"My Hero Academia (2016)",
+		"Fairy Tail (2009)",
+		"Dragon Ball Super (2015)",
+		"Samurai Champloo (2004)",
+		"Dragon Ball GT (1996)",
+	}
+
+	if got := runExample(t, ExampleRelated); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestExampleSeasons(t *testing.T) {
+	// only the start of the specials is checked, as episodes are added to the show.
+	want := []string{
+		"One Piece - (1999)",
+		"========================",
+		"Specials",
+		"========================",
+		"1 - One Piece: Defeat the Pirate Ganzack! (OVA 1)",
+		"2 - One Piece: The Movie",
+		"3 - Adventure in the Ocean's Navel",
+	}
+
+	got := runExample(t, ExampleSeasons)
+	if len(got) < len(want) || !reflect.DeepEqual(got[:len(want)], want) {
+		t.Errorf("expected output starting with %v, got %v", want, got)
+	}
+}
+
+func TestClient_Seasons(t *testing.T) {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	sh := srv.AddShow(&trakttest.Show{Title: "One Piece", Year: 1999, Seasons: []*trakttest.Season{
+		{Number: 0, Episodes: []*trakttest.Episode{{Number: 1, Title: "Defeat the Pirate Ganzack!"}}},
+		{Number: 1, Episodes: []*trakttest.Episode{{Number: 1, Title: "I'm Luffy!"}, {Number: 2, Title: "Enter Zoro"}}},
+	}})
+
+	c := NewClient(trakt.New("client-id", srv.BackendConfig()))
+	it := c.Seasons(trakt.Slug(sh.IDs.Slug), &trakt.ExtendedListParams{Extended: trakt.ExtendedTypeEpisodes})
+
+	var got []string
+	for it.Next() {
+		s, err := it.Season()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for _, ep := range s.Episodes {
+			got = append(got, fmt.Sprintf("%d.%d %s", s.Number, ep.Number, ep.Title))
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"0.1 Defeat the Pirate Ganzack!", "1.1 I'm Luffy!", "1.2 Enter Zoro"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
 }
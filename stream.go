@@ -0,0 +1,108 @@
+package trakt
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrStop can be returned from the function supplied to ForEach to stop iterating early,
+// ForEach returns nil rather than the error.
+var ErrStop = errors.New("trakt: stop iterating")
+
+// ForEach calls fn with each entry in turn until the results are exhausted, fn returns an error
+// or the context is done. The context is checked before each entry, and after retrieving a page,
+// so that iterating stops between entries and between pages once it is cancelled:
+//
+//  err := show.Trending(params).Iter().ForEach(ctx, func(s *trakt.TrendingShow) error {
+//  	if s.Watchers < 100 {
+//  		return trakt.ErrStop
+//  	}
+//
+//  	fmt.Println(s.Title)
+//  	return nil
+//  })
+//
+// The error from fn is returned, unless it is ErrStop in which case nil is returned.
+// Otherwise the error from the iterator, or the context, is returned.
+//
+// Pages are retrieved using the context of the params the iterator was created with rather than
+// ctx, so cancelling ctx does not abort a page which is being retrieved, ForEach returns once it
+// has been. To also abort the request, set the same context on the params:
+//
+//  params := &trakt.FilterListParams{BasicListParams: trakt.BasicListParams{Context: ctx}}
+//  err := show.Trending(params).Iter().ForEach(ctx, fn)
+func (i *Iter[T]) ForEach(ctx context.Context, fn func(T) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !i.Next() {
+			return i.Err()
+		}
+
+		// check again as retrieving the next page may have taken some time.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		v, err := i.Current()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(v); err != nil {
+			if errors.Is(err, ErrStop) {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+// Stream sends each entry on the returned channel from a separate goroutine. Once the results
+// are exhausted, an error occurs or the context is done, the entry channel is closed and the final
+// error, which is nil if every entry was sent, is sent on the error channel:
+//
+//  items, errc := it.Stream(ctx)
+//  for item := range items {
+//  	...
+//  }
+//
+//  if err := <-errc; err != nil {
+//  	return err
+//  }
+//
+// Consumers which stop reading entries early should cancel the context so that the goroutine exits.
+// As with ForEach, a page which is being retrieved is only aborted if the context is also set on
+// the params.
+func (i *Iter[T]) Stream(ctx context.Context) (<-chan T, <-chan error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	items := make(chan T)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+
+		err := i.ForEach(ctx, func(v T) error {
+			select {
+			case items <- v:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		close(items)
+		errc <- err
+	}()
+
+	return items, errc
+}
@@ -383,6 +383,13 @@ type CollectionIterator interface {
 	Type() Type
 	Show() (*CollectedShow, error)
 	Movie() (*CollectedMovie, error)
+
+	// ShowIter returns the typed variant of the iterator for collected shows, decoding
+	// an entry returns ErrValidation if the iterator is not for shows.
+	ShowIter() *Iter[*CollectedShow]
+	// MovieIter returns the typed variant of the iterator for collected movies, decoding
+	// an entry returns ErrValidation if the iterator is not for movies.
+	MovieIter() *Iter[*CollectedMovie]
 }
 
 type CollectedMovie struct {
@@ -428,6 +435,13 @@ type WatchedIterator interface {
 	Type() Type
 	Show() (*WatchedShow, error)
 	Movie() (*WatchedMovie, error)
+
+	// ShowIter returns the typed variant of the iterator for watched shows, decoding
+	// an entry returns ErrValidation if the iterator is not for shows.
+	ShowIter() *Iter[*WatchedShow]
+	// MovieIter returns the typed variant of the iterator for watched movies, decoding
+	// an entry returns ErrValidation if the iterator is not for movies.
+	MovieIter() *Iter[*WatchedMovie]
 }
 
 type WatchedMovie struct {
@@ -460,6 +474,23 @@ type NotFound struct {
 	Episodes []*GenericElementParams `json:"episodes"`
 }
 
+// DefaultChunkSize the default maximum amount of items sent in a single sync add or remove request.
+const DefaultChunkSize = 1000
+
+// ChunkProgress is the progress of a sync write which has been split into chunks.
+type ChunkProgress struct {
+	// Chunk the number of the chunk which was sent, starting at 1.
+	Chunk int
+	// Chunks the total amount of chunks.
+	Chunks int
+	// Sent the amount of items which have been sent successfully.
+	Sent int
+	// Total the total amount of items in the write.
+	Total int
+	// Err the error the chunk failed with, no further chunks are sent.
+	Err error
+}
+
 type ChangeSet struct {
 	Movies   int64 `json:"movies"`
 	Episodes int64 `json:"episodes"`
@@ -470,6 +501,11 @@ type AddToCollectionResult struct {
 	Updated  *ChangeSet `json:"updated"`
 	Existing *ChangeSet `json:"existing"`
 	NotFound *NotFound  `json:"not_found"`
+
+	// Verified is set when the response to a verified write was lost, but the write was
+	// confirmed as applied using the users last activities. The counts are unavailable
+	// when this is set.
+	Verified bool `json:"-"`
 }
 
 type AddToHistoryResult = AddToCollectionResult
@@ -479,6 +515,11 @@ type AddToWatchListResult = AddToCollectionResult
 type RemoveFromCollectionResult struct {
 	Deleted  *ChangeSet `json:"deleted"`
 	NotFound *NotFound  `json:"not_found"`
+
+	// Verified is set when the response to a verified write was lost, but the write was
+	// confirmed as applied using the users last activities. The counts are unavailable
+	// when this is set.
+	Verified bool `json:"-"`
 }
 
 type RemoveFromWatchListResult = RemoveFromCollectionResult
@@ -499,6 +540,9 @@ func (h *HistoryIterator) History() (*History, error) {
 	return rcv, h.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (h *HistoryIterator) Iter() *Iter[*History] { return newIter(h, h.History) }
+
 type RemoveFromHistoryResult struct {
 	RemoveFromCollectionResult
 	NotFound *struct {
@@ -526,6 +570,9 @@ func (w *WatchListEntryIterator) Entry() (*WatchListEntry, error) {
 	return rcv, w.Scan(rcv)
 }
 
+// Iter returns the typed variant of the iterator.
+func (w *WatchListEntryIterator) Iter() *Iter[*WatchListEntry] { return newIter(w, w.Entry) }
+
 // Applied attempts to retrieve the applied sort type on
 // a users watchlist.
 func (w *WatchListEntryIterator) Applied() *SortPreference {
@@ -0,0 +1,426 @@
+package sync
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/queue"
+)
+
+// itemKeys the keys in the body of a sync write which contain items, in the order they are chunked.
+var itemKeys = []string{"movies", "shows", "seasons", "episodes", "ids"}
+
+// childKeys the key which contains the children of a show at each depth, i.e the seasons of a show
+// and then the episodes of a season. Shows are split on their children so that a show with a large
+// amount of episodes can be spread over several chunks.
+var childKeys = []string{"seasons", "episodes"}
+
+// aggregate merges the results of each chunk of a write into a single result.
+type aggregate interface {
+	// result returns a new receiver for the result of a single chunk.
+	result() interface{}
+	// merge merges the result of a single chunk into the aggregate.
+	merge(v interface{}, verified bool)
+}
+
+// chunkParams the parameters used to send a single chunk, the body is sent as is.
+type chunkParams struct {
+	trakt.Params
+	body json.RawMessage
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (c *chunkParams) MarshalJSON() ([]byte, error) { return c.body, nil }
+
+// send performs a sync write, splitting it into chunks of at most ChunkSize items when it contains
+// more items than that. Chunks are sent in order, each chunk is a separate write request so it is
+// subject to the write rate limit of the backend. The result of each chunk is merged into agg and
+// sending stops at the first chunk which fails, leaving agg with the results of the chunks sent.
+// With dry-run enabled every chunk is built and the returned *trakt.DryRunError contains them all.
+// A chunk which is queued by the queue middleware has not failed, it is sent later, so the remaining
+// chunks are also sent, or queued behind it, and the error of the first queued chunk is returned.
+func (c *Client) send(path string, params trakt.ParamsContainer, p *trakt.Params, a activity, agg aggregate) error {
+	size := p.ChunkSize
+	if size == 0 {
+		size = trakt.DefaultChunkSize
+	}
+
+	total := count(params)
+	var body map[string]interface{}
+	var chunks []*chunk
+	if size > 0 && total > size {
+		body, chunks = split(params, size)
+	}
+
+	if len(chunks) <= 1 {
+		rcv := agg.result()
+		verified, err := c.write(path, params, p, rcv, a)
+		if err == nil {
+			agg.merge(rcv, verified)
+		}
+
+		progress(p, &trakt.ChunkProgress{Chunk: 1, Chunks: 1, Sent: sent(err, total, 0), Total: total, Err: err})
+		return err
+	}
+
+	var n int
+	var queued error
+	var dryRun []*trakt.DryRunRequest
+	for i, ch := range chunks {
+		b, err := chunkBody(body, ch)
+		if err != nil {
+			return err
+		}
+
+		cp := &chunkParams{Params: *p, body: b}
+		rcv := agg.result()
+		verified, err := c.write(path, cp, &cp.Params, rcv, a)
+		if err == nil {
+			agg.merge(rcv, verified)
+		}
+
+		n = sent(err, n+ch.n, n)
+		progress(p, &trakt.ChunkProgress{Chunk: i + 1, Chunks: len(chunks), Sent: n, Total: total, Err: err})
+
+		var dr *trakt.DryRunError
+		if errors.As(err, &dr) {
+			dryRun = append(dryRun, dr.Request)
+			continue
+		}
+
+		if errors.Is(err, queue.ErrQueued) {
+			if queued == nil {
+				queued = err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(dryRun) > 0 {
+		return &trakt.DryRunError{Request: dryRun[0], Chunks: dryRun}
+	}
+
+	return queued
+}
+
+// sent returns the amount of items sent depending on whether the chunk failed.
+func sent(err error, ok, failed int) int {
+	if err != nil {
+		return failed
+	}
+
+	return ok
+}
+
+// progress reports the progress of a write to the callback defined on the params.
+func progress(p *trakt.Params, cp *trakt.ChunkProgress) {
+	if p.OnChunk != nil {
+		p.OnChunk(cp)
+	}
+}
+
+// chunk a set of items to send in a single request.
+type chunk struct {
+	items map[string][]interface{}
+	n     int
+}
+
+// split decodes the body of a write and splits its items into chunks of at most size items. Each
+// movie, season, episode and history ID is a single item, as is each episode of a show, or each
+// season of a show if it does not define any episodes. The decoded body is returned along with
+// the chunks. A write which cannot be decoded is not split.
+func split(params trakt.ParamsContainer, size int) (map[string]interface{}, []*chunk) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil, nil
+	}
+
+	var body map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&body); err != nil {
+		return nil, nil
+	}
+
+	c := &chunker{size: size}
+	for _, key := range itemKeys {
+		items, _ := body[key].([]interface{})
+		for _, item := range items {
+			if key == "shows" {
+				c.walk(key, nil, item)
+				continue
+			}
+
+			c.add(key, nil, item)
+		}
+	}
+
+	if c.cur != nil {
+		c.chunks = append(c.chunks, c.cur)
+	}
+
+	return body, c.chunks
+}
+
+// count counts the items of a write in the same way as split, but without encoding the write so
+// that a write which does not need to be split is only encoded when it is sent.
+func count(params trakt.ParamsContainer) int {
+	v := reflect.Indirect(reflect.ValueOf(params))
+
+	var n int
+	for _, key := range itemKeys {
+		items := field(v, key)
+		for i := 0; i < items.Len(); i++ {
+			if key == "shows" {
+				n += countChildren(items.Index(i), 0)
+				continue
+			}
+
+			n++
+		}
+	}
+
+	return n
+}
+
+// countChildren counts the items of a show, each of its children at the deepest depth is an item.
+func countChildren(item reflect.Value, depth int) int {
+	children := field(reflect.Indirect(item), childKeys[depth])
+	if children.Len() == 0 {
+		return 1
+	}
+
+	var n int
+	for i := 0; i < children.Len(); i++ {
+		if depth+1 >= len(childKeys) {
+			n++
+			continue
+		}
+
+		n += countChildren(children.Index(i), depth+1)
+	}
+
+	return n
+}
+
+// field returns the slice held by the field of v which is encoded using the JSON key, an empty
+// slice is returned if v does not have one.
+func field(v reflect.Value, key string) reflect.Value {
+	if v.Kind() == reflect.Struct {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			if name == key && v.Field(i).Kind() == reflect.Slice {
+				return v.Field(i)
+			}
+		}
+	}
+
+	return reflect.ValueOf([]interface{}{})
+}
+
+// chunkBody generates the body of a single chunk, any properties of the body which are not
+// items are sent with every chunk.
+func chunkBody(body map[string]interface{}, ch *chunk) ([]byte, error) {
+	m := make(map[string]interface{}, len(body))
+	for k, v := range body {
+		m[k] = v
+	}
+
+	for _, key := range itemKeys {
+		delete(m, key)
+		if items, ok := ch.items[key]; ok {
+			m[key] = items
+		}
+	}
+
+	return json.Marshal(m)
+}
+
+// ancestor an item which contains the item being added to a chunk, i.e the show of a season.
+type ancestor struct {
+	id   int
+	item map[string]interface{}
+}
+
+// open an ancestor which has been added to the current chunk, so that its
+// remaining children can be added to it.
+type open struct {
+	id    int
+	clone map[string]interface{}
+}
+
+// chunker splits items into chunks.
+type chunker struct {
+	size   int
+	total  int
+	chunks []*chunk
+	cur    *chunk
+	open   []*open
+	// seq the ID of the last ancestor.
+	seq int
+}
+
+// walk adds an item to the chunks, descending in to its children so that each child
+// is added as a separate item.
+func (c *chunker) walk(key string, parents []*ancestor, item interface{}) {
+	m, ok := item.(map[string]interface{})
+	depth := len(parents)
+	if !ok || depth >= len(childKeys) {
+		c.add(key, parents, item)
+		return
+	}
+
+	children, _ := m[childKeys[depth]].([]interface{})
+	if len(children) == 0 {
+		c.add(key, parents, item)
+		return
+	}
+
+	c.seq++
+	a := &ancestor{id: c.seq, item: m}
+	for _, child := range children {
+		c.walk(key, append(parents[:depth:depth], a), child)
+	}
+}
+
+// add adds a single item to the current chunk, starting a new chunk when the current one is full.
+// The ancestors of the item are added to the chunk if they have not been already.
+func (c *chunker) add(key string, parents []*ancestor, item interface{}) {
+	c.total++
+	if c.cur != nil && c.size > 0 && c.cur.n >= c.size {
+		c.chunks = append(c.chunks, c.cur)
+		c.cur = nil
+	}
+
+	if c.cur == nil {
+		c.cur = &chunk{items: make(map[string][]interface{})}
+		c.open = nil
+	}
+
+	// reuse the ancestors which are already open in this chunk, the remaining ancestors are
+	// added as a copy which only contains the children which are added to this chunk.
+	d := 0
+	for d < len(parents) && d < len(c.open) && parents[d].id == c.open[d].id {
+		d++
+	}
+
+	c.open = c.open[:d]
+	for ; d < len(parents); d++ {
+		clone := make(map[string]interface{}, len(parents[d].item))
+		for k, v := range parents[d].item {
+			clone[k] = v
+		}
+
+		clone[childKeys[d]] = []interface{}{}
+		c.attach(key, d, clone)
+		c.open = append(c.open, &open{id: parents[d].id, clone: clone})
+	}
+
+	c.attach(key, len(parents), item)
+	c.cur.n++
+}
+
+// attach appends an item at the supplied depth, either to the chunk or to its open parent.
+func (c *chunker) attach(key string, depth int, item interface{}) {
+	if depth == 0 {
+		c.cur.items[key] = append(c.cur.items[key], item)
+		return
+	}
+
+	parent := c.open[depth-1].clone
+	k := childKeys[depth-1]
+	parent[k] = append(parent[k].([]interface{}), item)
+}
+
+// added aggregates the results of a write which adds items.
+type added struct{ r *trakt.AddToCollectionResult }
+
+func (a added) result() interface{} { return &trakt.AddToCollectionResult{} }
+
+func (a added) merge(v interface{}, verified bool) {
+	r := v.(*trakt.AddToCollectionResult)
+	a.r.Added = mergeChangeSet(a.r.Added, r.Added)
+	a.r.Updated = mergeChangeSet(a.r.Updated, r.Updated)
+	a.r.Existing = mergeChangeSet(a.r.Existing, r.Existing)
+	a.r.NotFound = mergeNotFound(a.r.NotFound, r.NotFound)
+	a.r.Verified = a.r.Verified || verified
+}
+
+// removed aggregates the results of a write which removes items.
+type removed struct {
+	r *trakt.RemoveFromCollectionResult
+}
+
+func (a removed) result() interface{} { return &trakt.RemoveFromCollectionResult{} }
+
+func (a removed) merge(v interface{}, verified bool) {
+	r := v.(*trakt.RemoveFromCollectionResult)
+	a.r.Deleted = mergeChangeSet(a.r.Deleted, r.Deleted)
+	a.r.NotFound = mergeNotFound(a.r.NotFound, r.NotFound)
+	a.r.Verified = a.r.Verified || verified
+}
+
+// removedHistory aggregates the results of a write which removes items from the history,
+// which can also report history IDs as not found.
+type removedHistory struct {
+	r *trakt.RemoveFromHistoryResult
+}
+
+func (a removedHistory) result() interface{} { return &trakt.RemoveFromHistoryResult{} }
+
+func (a removedHistory) merge(v interface{}, verified bool) {
+	r := v.(*trakt.RemoveFromHistoryResult)
+	a.r.Deleted = mergeChangeSet(a.r.Deleted, r.Deleted)
+	a.r.Verified = a.r.Verified || verified
+
+	switch {
+	case r.NotFound == nil:
+	case a.r.NotFound == nil:
+		a.r.NotFound = r.NotFound
+	default:
+		a.r.NotFound.NotFound = *mergeNotFound(&a.r.NotFound.NotFound, &r.NotFound.NotFound)
+		a.r.NotFound.IDs = append(a.r.NotFound.IDs, r.NotFound.IDs...)
+	}
+}
+
+// mergeChangeSet adds the counts of src to dst.
+func mergeChangeSet(dst, src *trakt.ChangeSet) *trakt.ChangeSet {
+	if dst == nil || src == nil {
+		if dst == nil {
+			return src
+		}
+
+		return dst
+	}
+
+	dst.Movies += src.Movies
+	dst.Episodes += src.Episodes
+	return dst
+}
+
+// mergeNotFound appends the items in src to dst.
+func mergeNotFound(dst, src *trakt.NotFound) *trakt.NotFound {
+	if dst == nil || src == nil {
+		if dst == nil {
+			return src
+		}
+
+		return dst
+	}
+
+	dst.Movies = append(dst.Movies, src.Movies...)
+	dst.Shows = append(dst.Shows, src.Shows...)
+	dst.Seasons = append(dst.Seasons, src.Seasons...)
+	dst.Episodes = append(dst.Episodes, src.Episodes...)
+	return dst
+}
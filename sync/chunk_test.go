@@ -0,0 +1,95 @@
+package sync_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/sync"
+)
+
+func TestChunkedWrites(t *testing.T) {
+	movies := make([]*trakt.MediaHistoryParams, 5)
+	for i := range movies {
+		movies[i] = &trakt.MediaHistoryParams{IDs: trakt.MediaIDs{Trakt: trakt.ID(i + 1)}}
+	}
+
+	show := &trakt.ShowHistoryParams{
+		IDs: trakt.MediaIDs{Trakt: 1},
+		Seasons: []*trakt.SeasonHistoryParams{
+			{Number: 1, Episodes: []*trakt.EpisodeHistoryParams{{Number: 1}, {Number: 2}, {Number: 3}}},
+			{Number: 2},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		size      int
+		dryRun    bool
+		requests  int32
+		chunks    int
+		total     int
+		dryChunks int
+	}{
+		{name: "under the limit", size: 10, requests: 1, chunks: 1, total: 9},
+		{name: "disabled", size: -1, requests: 1, chunks: 1, total: 9},
+		{name: "over the limit", size: 4, requests: 3, chunks: 3, total: 9},
+		{name: "dry-run under the limit", size: 10, dryRun: true, chunks: 1, total: 9},
+		{name: "dry-run over the limit", size: 4, dryRun: true, chunks: 3, total: 9, dryChunks: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&requests, 1)
+
+				var body map[string][]json.RawMessage
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				_, _ = w.Write([]byte(`{"added":{"movies":` + strconv.Itoa(len(body["movies"])) + `}}`))
+			}))
+			defer srv.Close()
+
+			c := sync.NewClient(trakt.New("key", &trakt.BackendConfig{
+				URL: srv.URL, WriteRateLimit: trakt.NoRateLimit, DryRun: tt.dryRun,
+			}))
+
+			var progress []*trakt.ChunkProgress
+			r, err := c.AddToHistory(&trakt.AddToHistoryParams{
+				Params: trakt.Params{
+					OAuth:     "token",
+					ChunkSize: tt.size,
+					OnChunk:   func(p *trakt.ChunkProgress) { progress = append(progress, p) },
+				},
+				Movies: movies,
+				Shows:  []*trakt.ShowHistoryParams{show},
+			})
+
+			var dr *trakt.DryRunError
+			if errors.As(err, &dr) != tt.dryRun {
+				t.Fatalf("expected dry-run to be %v, got %v", tt.dryRun, err)
+			}
+
+			if !tt.dryRun && (err != nil || r.Added.Movies != 5) {
+				t.Errorf("expected 5 movies to be added, got %+v, %v", r, err)
+			}
+
+			if dr != nil && len(dr.Chunks) != tt.dryChunks {
+				t.Errorf("expected %d dry-run chunks, got %d", tt.dryChunks, len(dr.Chunks))
+			}
+
+			if requests != tt.requests {
+				t.Errorf("expected %d requests, got %d", tt.requests, requests)
+			}
+
+			if len(progress) != tt.chunks || progress[len(progress)-1].Total != tt.total {
+				t.Errorf("expected %d chunks of %d items, got %d", tt.chunks, tt.total, len(progress))
+			}
+		})
+	}
+}
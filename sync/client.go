@@ -6,9 +6,9 @@ import (
 	"github.com/jacklaaa89/trakt"
 )
 
-// client represents a sync client which gives us access to functions to sync
+// Client represents a sync client which gives us access to functions to sync
 // trakt with one or more media centres.
-type client struct{ b trakt.BaseClient }
+type Client struct{ b trakt.PathClient }
 
 // LastActivities returns all of the dates of the latest activity for a user.
 //
@@ -28,9 +28,9 @@ func LastActivities(params *trakt.Params) (*trakt.LastActivity, error) {
 // so you don't pull down a ton of data only to see nothing has actually changed.
 //
 //  - OAuth Required
-func (c *client) LastActivities(params *trakt.Params) (*trakt.LastActivity, error) {
+func (c *Client) LastActivities(params *trakt.Params) (*trakt.LastActivity, error) {
 	l := &trakt.LastActivity{}
-	err := c.b.Call(http.MethodGet, "/sync/last_activities", params, l)
+	err := c.b.CallPath(http.MethodGet, trakt.NewPath("/sync/last_activities"), params, l)
 	return l, err
 }
 
@@ -60,9 +60,9 @@ func Playbacks(params *trakt.ListPlaybackParams) *trakt.PlaybackIterator {
 // results for something like an "on deck" feature.
 //
 //  - OAuth Required
-func (c *client) Playbacks(params *trakt.ListPlaybackParams) *trakt.PlaybackIterator {
-	path := trakt.FormatURLPath("/sync/playback/%s", params.Type)
-	return &trakt.PlaybackIterator{BasicIterator: c.b.NewSimulatedIterator(http.MethodGet, path, params)}
+func (c *Client) Playbacks(params *trakt.ListPlaybackParams) *trakt.PlaybackIterator {
+	path := trakt.NewPath("/sync/playback/%s", params.Type)
+	return &trakt.PlaybackIterator{BasicIterator: c.b.NewSimulatedIteratorPath(http.MethodGet, path, params)}
 }
 
 // RemovePlaybacks removes a playback item from a user's playback progress list.
@@ -77,9 +77,9 @@ func RemovePlayback(id int64, params *trakt.RemovePlaybackParams) error {
 // an error with the code "ErrorCodeNotFound" will be returned if the playback was not found.
 //
 //  - OAuth Required
-func (c *client) RemovePlayback(id int64, params *trakt.RemovePlaybackParams) error {
-	path := trakt.FormatURLPath("/sync/playback/%s", params.Type)
-	return c.b.Call(http.MethodDelete, path, params, nil)
+func (c *Client) RemovePlayback(id int64, params *trakt.RemovePlaybackParams) error {
+	path := trakt.NewPath("/sync/playback/%s", params.Type)
+	return c.b.CallPath(http.MethodDelete, path, params, nil)
 }
 
 // Collection returns all collected items in a user's collection. A collected item indicates availability to watch
@@ -126,7 +126,7 @@ func Collection(params *trakt.ListCollectionParams) trakt.CollectionIterator {
 //
 //  - OAuth Required
 //  - Extended Info
-func (c *client) Collection(params *trakt.ListCollectionParams) trakt.CollectionIterator {
+func (c *Client) Collection(params *trakt.ListCollectionParams) trakt.CollectionIterator {
 	if params.Type == trakt.TypeMovie {
 		return c.movieCollection(params)
 	}
@@ -162,9 +162,13 @@ func AddToCollection(params *trakt.AddToCollectionParams) (*trakt.AddToCollectio
 // This includes the CollectedAt value and any other metadata.
 //
 //  - OAuth Required
-func (c *client) AddToCollection(params *trakt.AddToCollectionParams) (*trakt.AddToCollectionResult, error) {
+func (c *Client) AddToCollection(params *trakt.AddToCollectionParams) (*trakt.AddToCollectionResult, error) {
+	if params == nil {
+		params = &trakt.AddToCollectionParams{}
+	}
+
 	rcv := &trakt.AddToCollectionResult{}
-	err := c.b.Call(http.MethodPost, "/sync/collection", params, &rcv)
+	err := c.send("/sync/collection", params, &params.Params, collectedAt, added{rcv})
 	return rcv, err
 }
 
@@ -178,9 +182,13 @@ func RemoveFromCollection(params *trakt.RemoveFromCollectionParams) (*trakt.Remo
 // RemoveFromCollection removes one or more items from a user's collection.
 //
 //  - OAuth Required
-func (c *client) RemoveFromCollection(params *trakt.RemoveFromCollectionParams) (*trakt.RemoveFromCollectionResult, error) {
+func (c *Client) RemoveFromCollection(params *trakt.RemoveFromCollectionParams) (*trakt.RemoveFromCollectionResult, error) {
+	if params == nil {
+		params = &trakt.RemoveFromCollectionParams{}
+	}
+
 	rcv := &trakt.RemoveFromCollectionResult{}
-	err := c.b.Call(http.MethodPost, "/sync/collection/remove", params, &rcv)
+	err := c.send("/sync/collection/remove", params, &params.Params, collectedAt, removed{rcv})
 	return rcv, err
 }
 
@@ -212,7 +220,7 @@ func Watched(params *trakt.ListCollectionParams) trakt.WatchedIterator { return
 //
 //  - OAuth Required
 //  - Extended Info
-func (c *client) Watched(params *trakt.ListCollectionParams) trakt.WatchedIterator {
+func (c *Client) Watched(params *trakt.ListCollectionParams) trakt.WatchedIterator {
 	if params.Type == trakt.TypeMovie {
 		return c.watchedMovies(params)
 	}
@@ -246,9 +254,9 @@ func History(params *trakt.ListHistoryParams) *trakt.HistoryIterator {
 //  - OAuth Required
 //  - Pagination
 //  - Extended Info
-func (c *client) History(params *trakt.ListHistoryParams) *trakt.HistoryIterator {
-	path := trakt.FormatURLPath("/sync/history/%s/%s", params.Type, params.ID)
-	return &trakt.HistoryIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) History(params *trakt.ListHistoryParams) *trakt.HistoryIterator {
+	path := trakt.NewPath("/sync/history/%s/%s", params.Type, params.ID)
+	return &trakt.HistoryIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // AddToHistory adds items to a user's watch history. Accepts shows, seasons, episodes and movies.
@@ -271,9 +279,13 @@ func AddToHistory(params *trakt.AddToHistoryParams) (*trakt.AddToHistoryResult,
 // watches from a media center.
 //
 //  - OAuth Required
-func (c *client) AddToHistory(params *trakt.AddToHistoryParams) (*trakt.AddToHistoryResult, error) {
+func (c *Client) AddToHistory(params *trakt.AddToHistoryParams) (*trakt.AddToHistoryResult, error) {
+	if params == nil {
+		params = &trakt.AddToHistoryParams{}
+	}
+
 	rcv := &trakt.AddToHistoryResult{}
-	err := c.b.Call(http.MethodPost, "/sync/history", params, &rcv)
+	err := c.send("/sync/history", params, &params.Params, watchedAt, added{rcv})
 	return rcv, err
 }
 
@@ -297,9 +309,13 @@ func RemoveFromHistory(params *trakt.RemoveFromHistoryParams) (*trakt.RemoveFrom
 // The "History" method will return an individual id (64-bit integer) for each history item.
 //
 //  - OAuth Required
-func (c *client) RemoveFromHistory(params *trakt.RemoveFromHistoryParams) (*trakt.RemoveFromHistoryResult, error) {
+func (c *Client) RemoveFromHistory(params *trakt.RemoveFromHistoryParams) (*trakt.RemoveFromHistoryResult, error) {
+	if params == nil {
+		params = &trakt.RemoveFromHistoryParams{}
+	}
+
 	rcv := &trakt.RemoveFromHistoryResult{}
-	err := c.b.Call(http.MethodPost, "/sync/history/remove", params, &rcv)
+	err := c.send("/sync/history/remove", params, &params.Params, watchedAt, removedHistory{rcv})
 	return rcv, err
 }
 
@@ -319,9 +335,9 @@ func Ratings(params *trakt.ListRatingParams) *trakt.RatingIterator {
 //  - OAuth Required
 //  - Pagination
 //  - Extended Info
-func (c *client) Ratings(params *trakt.ListRatingParams) *trakt.RatingIterator {
-	path := trakt.FormatURLPath("/sync/ratings/%s/%s", params.Type.Plural(), params.Ratings)
-	return &trakt.RatingIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) Ratings(params *trakt.ListRatingParams) *trakt.RatingIterator {
+	path := trakt.NewPath("/sync/ratings/%s/%s", params.Type.Plural(), params.Ratings)
+	return &trakt.RatingIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // AddRatings rates one or more items. Accepts shows, seasons, episodes and movies. If only a show is passed,
@@ -338,9 +354,13 @@ func AddRatings(params *trakt.AddRatingsParams) (*trakt.AddRatingsResult, error)
 // Send a RatedAt time to mark items as rated in the past. This is useful for syncing ratings from a media center.
 //
 //  - OAuth Required
-func (c *client) AddRatings(params *trakt.AddRatingsParams) (*trakt.AddRatingsResult, error) {
+func (c *Client) AddRatings(params *trakt.AddRatingsParams) (*trakt.AddRatingsResult, error) {
+	if params == nil {
+		params = &trakt.AddRatingsParams{}
+	}
+
 	rcv := &trakt.AddRatingsResult{}
-	err := c.b.Call(http.MethodPost, "/sync/ratings", params, &rcv)
+	err := c.send("/sync/ratings", params, &params.Params, ratedAt, added{rcv})
 	return rcv, err
 }
 
@@ -354,9 +374,13 @@ func RemoveRatings(params *trakt.RemoveRatingsParams) (*trakt.RemoveRatingsResul
 // RemoveRatings removes ratings for one or more items.
 //
 //  - OAuth Required
-func (c *client) RemoveRatings(params *trakt.RemoveRatingsParams) (*trakt.RemoveRatingsResult, error) {
+func (c *Client) RemoveRatings(params *trakt.RemoveRatingsParams) (*trakt.RemoveRatingsResult, error) {
+	if params == nil {
+		params = &trakt.RemoveRatingsParams{}
+	}
+
 	rcv := &trakt.RemoveRatingsResult{}
-	err := c.b.Call(http.MethodPost, "/sync/ratings/remove", params, &rcv)
+	err := c.send("/sync/ratings/remove", params, &params.Params, ratedAt, removed{rcv})
 	return rcv, err
 }
 
@@ -388,9 +412,9 @@ func WatchList(params *trakt.ListWatchListParams) *trakt.WatchListEntryIterator
 //  - OAuth Required
 //  - Pagination
 //  - Extended Info
-func (c *client) WatchList(params *trakt.ListWatchListParams) *trakt.WatchListEntryIterator {
-	path := trakt.FormatURLPath("/sync/watchlist/%s/%s", params.Type.Plural(), params.Sort)
-	return &trakt.WatchListEntryIterator{Iterator: c.b.NewIterator(http.MethodGet, path, params)}
+func (c *Client) WatchList(params *trakt.ListWatchListParams) *trakt.WatchListEntryIterator {
+	path := trakt.NewPath("/sync/watchlist/%s/%s", params.Type.Plural(), params.Sort)
+	return &trakt.WatchListEntryIterator{Iterator: c.b.NewIteratorPath(http.MethodGet, path, params)}
 }
 
 // AddToWatchList adds one of more items to a user's watchlist. Accepts shows, seasons, episodes and movies.
@@ -407,9 +431,13 @@ func AddToWatchList(params *trakt.AddToWatchListParams) (*trakt.AddToWatchListRe
 // seasons will be added.
 //
 //  - OAuth Required
-func (c *client) AddToWatchList(params *trakt.AddToWatchListParams) (*trakt.AddToWatchListResult, error) {
+func (c *Client) AddToWatchList(params *trakt.AddToWatchListParams) (*trakt.AddToWatchListResult, error) {
+	if params == nil {
+		params = &trakt.AddToWatchListParams{}
+	}
+
 	rcv := &trakt.AddToWatchListResult{}
-	err := c.b.Call(http.MethodPost, "/sync/watchlist", params, &rcv)
+	err := c.send("/sync/watchlist", params, &params.Params, watchListedAt, added{rcv})
 	return rcv, err
 }
 
@@ -423,60 +451,66 @@ func RemoveFromWatchList(params *trakt.RemoveFromWatchListParams) (*trakt.Remove
 // RemoveFromWatchList removes one or more items from a user's watchlist.
 //
 //  - OAuth Required
-func (c *client) RemoveFromWatchList(params *trakt.RemoveFromWatchListParams) (*trakt.RemoveFromWatchListResult, error) {
+func (c *Client) RemoveFromWatchList(params *trakt.RemoveFromWatchListParams) (*trakt.RemoveFromWatchListResult, error) {
+	if params == nil {
+		params = &trakt.RemoveFromWatchListParams{}
+	}
+
 	rcv := &trakt.RemoveFromWatchListResult{}
-	err := c.b.Call(http.MethodPost, "/sync/watchlist/remove", params, &rcv)
+	err := c.send("/sync/watchlist/remove", params, &params.Params, watchListedAt, removed{rcv})
 	return rcv, err
 }
 
 // movieCollection generates an iterator for collected movies.
-func (c *client) movieCollection(params *trakt.ListCollectionParams) *collection {
+func (c *Client) movieCollection(params *trakt.ListCollectionParams) *collection {
 	return c.newCollectionIterator(trakt.TypeMovie, params)
 }
 
 // showCollection generates an iterator for collected shows.
-func (c *client) showCollection(params *trakt.ListCollectionParams) *collection {
+func (c *Client) showCollection(params *trakt.ListCollectionParams) *collection {
 	return c.newCollectionIterator(trakt.TypeShow, params)
 }
 
 // newWatchedIterator generates an iterator for either watched shows or movies
 // based on the type.
-func (c *client) newCollectionIterator(t trakt.Type, p *trakt.ListCollectionParams) *collection {
-	path := trakt.FormatURLPath("/sync/collection/%s", t.Plural())
+func (c *Client) newCollectionIterator(t trakt.Type, p *trakt.ListCollectionParams) *collection {
+	path := trakt.NewPath("/sync/collection/%s", t.Plural())
 	return &collection{
 		genericIterator: genericIterator{
-			BasicIterator: c.b.NewSimulatedIteratorWithCondition(
+			BasicIterator: c.b.NewSimulatedIteratorWithConditionPath(
 				http.MethodGet, path, p, func() error {
-					return compareType(path, p.Type, t)
+					return compareType(path.Path, p.Type, t)
 				},
 			),
-			typ: p.Type,
+			typ:  p.Type,
+			path: path.Path,
 		},
 	}
 }
 
 // watchedMovies generates an iterator for watched movies.
-func (c *client) watchedMovies(params *trakt.ListWatchedParams) *watched {
+func (c *Client) watchedMovies(params *trakt.ListWatchedParams) *watched {
 	return c.newWatchedIterator(trakt.TypeMovie, params)
 }
 
 // watchedShows generates an iterator for watched shows.
-func (c *client) watchedShows(params *trakt.ListWatchedParams) *watched {
+func (c *Client) watchedShows(params *trakt.ListWatchedParams) *watched {
 	return c.newWatchedIterator(trakt.TypeShow, params)
 }
 
 // newWatchedIterator generates an iterator for either watched shows or movies
 // based on the type.
-func (c *client) newWatchedIterator(t trakt.Type, p *trakt.ListWatchedParams) *watched {
-	path := trakt.FormatURLPath("/sync/watched/%s", t.Plural())
+func (c *Client) newWatchedIterator(t trakt.Type, p *trakt.ListWatchedParams) *watched {
+	path := trakt.NewPath("/sync/watched/%s", t.Plural())
 	return &watched{
 		genericIterator: genericIterator{
-			BasicIterator: c.b.NewSimulatedIteratorWithCondition(
+			BasicIterator: c.b.NewSimulatedIteratorWithConditionPath(
 				http.MethodGet, path, p, func() error {
-					return compareType(path, p.Type, t)
+					return compareType(path.Path, p.Type, t)
 				},
 			),
-			typ: p.Type,
+			typ:  p.Type,
+			path: path.Path,
 		},
 	}
 }
@@ -489,8 +523,15 @@ type genericIterator struct {
 	// typ the type of object which this iterator represents
 	// can either be show or movie.
 	typ trakt.Type
+
+	// path the path the iterator retrieves entries from.
+	path string
 }
 
+// expect returns an error if the entries of the iterator are not of the supplied
+// type, so that an entry is never decoded into the wrong type.
+func (g *genericIterator) expect(t trakt.Type) error { return compareType(g.path, g.typ, t) }
+
 // Type implements both WatchedIterator and CollectionIterator interfaces.
 // returns the type so the user knows which entry to use.
 func (g *genericIterator) Type() trakt.Type { return g.typ }
@@ -513,6 +554,28 @@ func (c *collection) Movie() (*trakt.CollectedMovie, error) {
 	return rcv, c.Scan(rcv)
 }
 
+// ShowIter implements CollectionIterator interface.
+func (c *collection) ShowIter() *trakt.Iter[*trakt.CollectedShow] {
+	return trakt.NewIterFunc(c, func() (*trakt.CollectedShow, error) {
+		if err := c.expect(trakt.TypeShow); err != nil {
+			return nil, err
+		}
+
+		return c.Show()
+	})
+}
+
+// MovieIter implements CollectionIterator interface.
+func (c *collection) MovieIter() *trakt.Iter[*trakt.CollectedMovie] {
+	return trakt.NewIterFunc(c, func() (*trakt.CollectedMovie, error) {
+		if err := c.expect(trakt.TypeMovie); err != nil {
+			return nil, err
+		}
+
+		return c.Movie()
+	})
+}
+
 // watched an implementation of a WatchedIterator
 // uses the internal iterator defined on the genericIterator
 // to attempt to scan and cast to either a watched show or movie
@@ -531,6 +594,28 @@ func (c *watched) Movie() (*trakt.WatchedMovie, error) {
 	return rcv, c.Scan(rcv)
 }
 
+// ShowIter implements WatchedIterator interface.
+func (c *watched) ShowIter() *trakt.Iter[*trakt.WatchedShow] {
+	return trakt.NewIterFunc(c, func() (*trakt.WatchedShow, error) {
+		if err := c.expect(trakt.TypeShow); err != nil {
+			return nil, err
+		}
+
+		return c.Show()
+	})
+}
+
+// MovieIter implements WatchedIterator interface.
+func (c *watched) MovieIter() *trakt.Iter[*trakt.WatchedMovie] {
+	return trakt.NewIterFunc(c, func() (*trakt.WatchedMovie, error) {
+		if err := c.expect(trakt.TypeMovie); err != nil {
+			return nil, err
+		}
+
+		return c.Movie()
+	})
+}
+
 // compareType helper function to compare to types to see if they are equal.
 // the path is required to generate the standard error signature if the types do not
 // match.
@@ -547,5 +632,9 @@ func compareType(path string, a, b trakt.Type) error {
 	}
 }
 
+// NewClient initialises a new sync client using the supplied trakt client rather
+// than the globally defined backend configuration.
+func NewClient(c trakt.BaseClient) *Client { return &Client{trakt.Paths(c)} }
+
 // getC initialises a new sync client using the currently configured backend.
-func getC() *client { return &client{trakt.NewClient()} }
+func getC() *Client { return &Client{trakt.Paths(trakt.NewClient())} }
@@ -34,4 +34,33 @@
 // Cleaning a collection involves comparing the trakt collection to what exists locally. This will remove items
 // from the trakt collection if they don't exist locally anymore. You should make this clear to the user
 // that data might be removed from trakt.
+//
+// Verified Writes
+//
+// Write requests are not retried by default, as a retried write which was already applied could create duplicate
+// plays. You can set Verify on the params of the add and remove functions to enable verification. If a request fails
+// in a way which leaves it unknown whether it was applied, the user's last activities are checked once the delay from
+// the backend's retry policy has elapsed. If the write was applied, the result will have Verified set, otherwise
+// nothing has changed and the request is resent.
+//
+// Chunked Writes
+//
+// Large writes, such as importing a library with thousands of plays, are split into chunks of at most ChunkSize
+// items which are sent in order, each chunk is a separate request which waits on the write rate limit. Each movie,
+// season, episode and history ID is an item, as is each episode of a show, so a show with many episodes can be
+// spread over several chunks. The results of the chunks are merged into a single result and the progress is
+// reported after each chunk using OnChunk:
+//
+//  res, err := sync.AddToHistory(&trakt.AddToHistoryParams{
+//  	Params: trakt.Params{OAuth: token, ChunkSize: 500, OnChunk: func(p *trakt.ChunkProgress) {
+//  		fmt.Printf("sent %d of %d items\n", p.Sent, p.Total)
+//  	}},
+//  	Movies: movies,
+//  })
+//
+// If a chunk fails no further chunks are sent, the error is returned with the merged result of the chunks
+// which were sent. ChunkSize defaults to DefaultChunkSize, a negative value disables chunking. A write with
+// dry-run enabled is split in the same way, the Chunks of the returned *trakt.DryRunError contain every chunk.
+// A chunk which is queued by the queue middleware does not stop the write, the remaining chunks are queued
+// behind it and an error matching queue.ErrQueued is returned once every chunk has been sent or queued.
 package sync
@@ -0,0 +1,53 @@
+package sync_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/sync"
+	"github.com/jacklaaa89/trakt/trakttest"
+)
+
+func TestCollectionIter(t *testing.T) {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	srv.AddShow(&trakttest.Show{
+		Title:   "The Office",
+		Year:    2005,
+		Seasons: []*trakttest.Season{{Number: 1, Episodes: []*trakttest.Episode{{Number: 1, Title: "Pilot"}}}},
+	})
+	token := srv.Authorize("sean")
+
+	c := sync.NewClient(trakt.New("client-id", srv.BackendConfig()))
+	_, err := c.AddToCollection(&trakt.AddToCollectionParams{
+		Params: trakt.Params{OAuth: token},
+		Shows:  []*trakt.ShowCollectionParams{{IDs: trakt.MediaIDs{Slug: "the-office-2005"}}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	list := func() trakt.CollectionIterator {
+		return c.Collection(&trakt.ListCollectionParams{ListParams: trakt.ListParams{OAuth: token}, Type: trakt.TypeShow})
+	}
+
+	shows := list().ShowIter()
+	if !shows.Next() {
+		t.Fatalf("expected a show, got %v", shows.Err())
+	}
+
+	if _, err := shows.Current(); errors.Is(err, trakt.ErrValidation) {
+		t.Errorf("expected a show to be decoded as a show, got %v", err)
+	}
+
+	movies := list().MovieIter()
+	if !movies.Next() {
+		t.Fatalf("expected an entry, got %v", movies.Err())
+	}
+
+	if _, err := movies.Current(); !errors.Is(err, trakt.ErrValidation) {
+		t.Errorf("expected a validation error decoding a show as a movie, got %v", err)
+	}
+}
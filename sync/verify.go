@@ -0,0 +1,179 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// maxVerifiedAttempts the maximum amount of times a verified write is sent.
+const maxVerifiedAttempts = 3
+
+// activity retrieves the latest time an activity was performed from a user's last activities.
+type activity func(l *trakt.LastActivity) time.Time
+
+// write performs a write request to path, unmarshalling the result into rcv.
+//
+// If verification is enabled on the params and the request fails in a way which leaves it unknown whether
+// the write was applied, the user's last activities are compared against those captured before the request
+// was performed. If the activity has been updated, the write was applied and is not resent, otherwise nothing
+// has changed and it is safe to resend the request. The backend's retry policy determines how long to wait
+// before the activities are checked, see delay.
+//
+// true is returned if the write was confirmed as applied using verification.
+func (c *Client) write(path string, params trakt.ParamsContainer, p *trakt.Params, rcv interface{}, a activity) (bool, error) {
+	if !p.Verify {
+		return false, c.b.CallPath(http.MethodPost, trakt.NewPath(path), params, rcv)
+	}
+
+	// capture the user's activities prior to performing the write
+	// so that we can compare against them after a failure.
+	ap := &trakt.Params{Context: p.Context, Headers: p.Headers, OAuth: p.OAuth}
+	before, err := c.LastActivities(ap)
+	if err != nil {
+		return false, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = c.b.CallPath(http.MethodPost, trakt.NewPath(path), params, rcv)
+		if err == nil || !trakt.IsAmbiguousFailure(err) || attempt >= maxVerifiedAttempts {
+			return false, err
+		}
+
+		if sErr := wait(p.Context, c.delay(err, attempt)); sErr != nil {
+			return false, sErr
+		}
+
+		after, vErr := c.LastActivities(ap)
+		if vErr != nil {
+			return false, err
+		}
+
+		if a(after).After(a(before)) {
+			return true, nil
+		}
+	}
+}
+
+// delay determines how long to wait after a failed verified write before the activities are checked.
+// The retry policy of the backend is used, writes are not retried by the backend so if the policy
+// declines to retry the write or the backend has no policy, the DefaultRetryPolicy delay is used.
+func (c *Client) delay(err error, attempt int) time.Duration {
+	ra := &trakt.RetryAttempt{Err: err, Method: http.MethodPost, Attempt: attempt - 1}
+
+	var traktErr *trakt.Error
+	if errors.As(err, &traktErr) {
+		ra.StatusCode, ra.Header = traktErr.HTTPStatusCode, traktErr.Header
+	}
+
+	if policy := trakt.RetryPolicyFor(c.b); policy != nil {
+		if retry, d := policy.Retry(ra); retry {
+			return d
+		}
+	}
+
+	_, d := (&trakt.DefaultRetryPolicy{MaxRetries: maxVerifiedAttempts}).Retry(ra)
+	return d
+}
+
+// wait waits for the supplied duration, returning early with the context
+// error if the context is done before the duration has elapsed.
+func wait(ctx context.Context, d time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// collectedAt retrieves the last time an item was added or removed from a user's collection.
+func collectedAt(l *trakt.LastActivity) time.Time {
+	var t time.Time
+	if l.Movies != nil {
+		t = latest(t, l.Movies.LastCollected)
+	}
+
+	if l.Episodes != nil {
+		t = latest(t, l.Episodes.LastCollected)
+	}
+
+	return t
+}
+
+// watchedAt retrieves the last time an item was added or removed from a user's history.
+func watchedAt(l *trakt.LastActivity) time.Time {
+	var t time.Time
+	if l.Movies != nil {
+		t = latest(t, l.Movies.LastWatched)
+	}
+
+	if l.Episodes != nil {
+		t = latest(t, l.Episodes.LastWatched)
+	}
+
+	return t
+}
+
+// ratedAt retrieves the last time an item was rated or had its rating removed.
+func ratedAt(l *trakt.LastActivity) time.Time {
+	var t time.Time
+	if l.Movies != nil {
+		t = latest(t, l.Movies.LastRated)
+	}
+
+	if l.Episodes != nil {
+		t = latest(t, l.Episodes.LastRated)
+	}
+
+	if l.Shows != nil {
+		t = latest(t, l.Shows.LastRated)
+	}
+
+	if l.Seasons != nil {
+		t = latest(t, l.Seasons.LastRated)
+	}
+
+	return t
+}
+
+// watchListedAt retrieves the last time an item was added or removed from a user's watchlist.
+func watchListedAt(l *trakt.LastActivity) time.Time {
+	var t time.Time
+	if l.Movies != nil {
+		t = latest(t, l.Movies.LastWatchListed)
+	}
+
+	if l.Episodes != nil {
+		t = latest(t, l.Episodes.LastWatchListed)
+	}
+
+	if l.Shows != nil {
+		t = latest(t, l.Shows.LastWatchListed)
+	}
+
+	if l.Seasons != nil {
+		t = latest(t, l.Seasons.LastWatchListed)
+	}
+
+	return t
+}
+
+// latest returns the latest of the two times.
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
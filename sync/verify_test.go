@@ -0,0 +1,120 @@
+package sync_test
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/sync"
+	"github.com/jacklaaa89/trakt/trakttest"
+)
+
+// loseResponse generates a middleware which fails the first write to path with a server error,
+// which leaves it unknown whether the write was applied. If applied is set the write is sent
+// to the backend and only its response is lost. The amount of writes sent is stored in sent.
+func loseResponse(path string, applied bool, sent *int32) trakt.Middleware {
+	return func(next trakt.Handler) trakt.Handler {
+		return func(r *trakt.Request, v interface{}) error {
+			if r.HTTPRequest.Method != http.MethodPost || r.HTTPRequest.URL.Path != path {
+				return next(r, v)
+			}
+
+			if atomic.AddInt32(sent, 1) > 1 {
+				return next(r, v)
+			}
+
+			if applied {
+				if err := next(r, v); err != nil {
+					return err
+				}
+			}
+
+			return &trakt.Error{HTTPStatusCode: http.StatusBadGateway, Resource: path}
+		}
+	}
+}
+
+func TestVerifiedWrites(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		verify   bool
+		applied  bool
+		sent     int32
+		plays    int
+		verified bool
+		err      bool
+	}{
+		{name: "history applied", path: "/sync/history", verify: true, applied: true, sent: 1, plays: 1, verified: true},
+		{name: "history not applied", path: "/sync/history", verify: true, sent: 2, plays: 1},
+		{name: "history without verify", path: "/sync/history", applied: true, sent: 1, plays: 1, err: true},
+		{name: "collection applied", path: "/sync/collection", verify: true, applied: true, sent: 1, verified: true},
+		{name: "collection not applied", path: "/sync/collection", verify: true, sent: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := trakttest.NewServer()
+			defer srv.Close()
+
+			token := srv.Authorize("sean")
+			m := srv.AddMovie(&trakttest.Movie{Title: "Tron", Year: 1982})
+
+			var sent int32
+			config := srv.BackendConfig()
+			config.RetryPolicy = &trakt.DefaultRetryPolicy{MaxRetries: 3, MinDelay: time.Millisecond, MaxDelay: time.Millisecond}
+			config.Middleware = []trakt.Middleware{loseResponse(tt.path, tt.applied, &sent)}
+			c := sync.NewClient(trakt.New("client-id", config))
+
+			p := trakt.Params{OAuth: token, Verify: tt.verify}
+
+			var verified bool
+			var err error
+			if tt.path == "/sync/history" {
+				var r *trakt.AddToHistoryResult
+				r, err = c.AddToHistory(&trakt.AddToHistoryParams{
+					Params: p, Movies: []*trakt.MediaHistoryParams{{IDs: m.IDs}},
+				})
+				verified = r.Verified
+			} else {
+				var r *trakt.AddToCollectionResult
+				r, err = c.AddToCollection(&trakt.AddToCollectionParams{
+					Params: p, Movies: []*trakt.MediaCollectionParams{{IDs: m.IDs}},
+				})
+				verified = r.Verified
+			}
+
+			if trakt.IsAmbiguousFailure(err) != tt.err {
+				t.Fatalf("expected an ambiguous failure to be %v, got %v", tt.err, err)
+			}
+
+			if !tt.err && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if sent != tt.sent {
+				t.Errorf("expected %d writes to be sent, got %d", tt.sent, sent)
+			}
+
+			if verified != tt.verified {
+				t.Errorf("expected verified to be %v, got %v", tt.verified, verified)
+			}
+
+			plays := 0
+			it := c.History(&trakt.ListHistoryParams{ListParams: trakt.ListParams{OAuth: token}})
+			for it.Next() {
+				plays++
+			}
+
+			if err := it.Err(); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if plays != tt.plays {
+				t.Errorf("expected %d plays, got %d", tt.plays, plays)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package trakt
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Span is a single traced operation. It has the same shape as an OpenTelemetry span,
+// so an OpenTelemetry span can be used with a thin shim which converts each Field
+// into an attribute.
+type Span interface {
+	// SetAttributes sets attributes on the span.
+	SetAttributes(attrs ...Field)
+	// AddEvent adds an event to the span.
+	AddEvent(name string, attrs ...Field)
+	// RecordError records an error on the span and marks the span as failed.
+	RecordError(err error)
+	// End completes the span.
+	End()
+}
+
+// Tracer starts new spans. It has the same shape as an OpenTelemetry tracer, i.e:
+//
+//  type otelTracer struct{ t trace.Tracer }
+//
+//  func (o otelTracer) Start(ctx context.Context, name string) (context.Context, trakt.Span) {
+//  	ctx, span := o.t.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//  	return ctx, otelSpan{span}
+//  }
+type Tracer interface {
+	// Start starts a new span as a child of any span in the context.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// The attributes set on the spans generated by a tracing observer, these follow
+// the OpenTelemetry semantic conventions for HTTP clients where one exists.
+const (
+	AttributeHTTPMethod      = "http.request.method"
+	AttributeHTTPRoute       = "http.route"
+	AttributeURLPath         = "url.path"
+	AttributeHTTPStatusCode  = "http.response.status_code"
+	AttributeHTTPResendCount = "http.request.resend_count"
+	AttributeRequestID       = "trakt.request_id"
+	AttributeCached          = "trakt.cached"
+	AttributeRetryAfter      = "trakt.retry_after"
+)
+
+// NewTracingObserver generates an Observer which traces every call using a span from the
+// supplied tracer. The span is named after the method and path template of the call, i.e
+// "GET /shows/%s/seasons", and retries and decode failures are added to the span as events.
+func NewTracingObserver(t Tracer) Observer { return &tracingObserver{t: t} }
+
+// tracingObserver an Observer which traces each call using a Span.
+type tracingObserver struct {
+	t     Tracer
+	spans sync.Map // map[*RequestInfo]Span
+}
+
+// RequestStarted implements Observer interface.
+func (o *tracingObserver) RequestStarted(r *RequestInfo) {
+	ctx := r.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, span := o.t.Start(ctx, r.Method+" "+r.PathTemplate)
+	span.SetAttributes(
+		F(AttributeHTTPMethod, r.Method),
+		F(AttributeHTTPRoute, r.PathTemplate),
+		F(AttributeURLPath, r.Path),
+	)
+
+	o.spans.Store(r, span)
+}
+
+// RequestRetried implements Observer interface.
+func (o *tracingObserver) RequestRetried(r *RequestInfo, a *RetryAttempt, wait time.Duration) {
+	if span, ok := o.span(r); ok {
+		span.AddEvent("retry",
+			F(AttributeHTTPResendCount, a.Attempt+1),
+			F(AttributeHTTPStatusCode, a.StatusCode),
+			F(AttributeRetryAfter, wait.String()),
+			F(AttributeRequestID, r.RequestID),
+		)
+	}
+}
+
+// DecodeFailed implements Observer interface.
+func (o *tracingObserver) DecodeFailed(r *RequestInfo, err error) {
+	if span, ok := o.span(r); ok {
+		span.AddEvent("decode_failed")
+		span.RecordError(err)
+	}
+}
+
+// ResponseReceived implements Observer interface.
+func (o *tracingObserver) ResponseReceived(r *RequestInfo, res *ResponseInfo) {
+	span, ok := o.span(r)
+	if !ok {
+		return
+	}
+
+	o.spans.Delete(r)
+	defer span.End()
+
+	span.SetAttributes(
+		F(AttributeHTTPStatusCode, res.StatusCode),
+		F(AttributeHTTPResendCount, res.Retries),
+		F(AttributeRequestID, r.RequestID),
+		F(AttributeCached, res.Cached),
+	)
+
+	if res.Err != nil {
+		span.RecordError(res.Err)
+	}
+}
+
+// span retrieves the span for the request.
+func (o *tracingObserver) span(r *RequestInfo) (Span, bool) {
+	v, ok := o.spans.Load(r)
+	if !ok {
+		return nil, false
+	}
+
+	return v.(Span), true
+}
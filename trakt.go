@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"math/rand"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -42,10 +41,15 @@ const (
 	maxNetworkRetriesDelay = 5000 * time.Millisecond
 	minNetworkRetriesDelay = 500 * time.Millisecond
 
+	// defaultMaxRateLimitRetries the default maximum number of times a rate limited request is retried.
+	defaultMaxRateLimitRetries = 3
+
 	// applicationTypeJSON the required content-type used in all requests.
 	applicationTypeJSON = "application/json"
 	// requestIDHeader the header which contains the requestID.
 	requestIDHeader = "X-Request-ID"
+	// apiKeyHeader the header which contains the client_id of the application.
+	apiKeyHeader = "trakt-api-key"
 )
 
 var (
@@ -69,7 +73,7 @@ var (
 
 type (
 	// iteratorFunc is a function which is used to generate an iterator.
-	iteratorFunc func(ListParamsContainer, queryFunc, bool) Iterator
+	iteratorFunc func(ListParamsContainer, endpoint, queryFunc, bool) Iterator
 	// Condition a function which can be set prior to attempting to retrieve a frame
 	// to determine if the arguments are valid. If the response of this function returns
 	// an error, then the error on the iterator is set to the error, and no more paging is performed.
@@ -91,9 +95,50 @@ func Staging() { setBackend(getBackendWithConfig(stagingConfig)) }
 // WithConfig sets up the API with the supplied config.
 func WithConfig(bk *BackendConfig) { setBackend(getBackendWithConfig(bk)) }
 
+// WithBackend sets up the API to use the supplied backend.
+func WithBackend(b Backend) { setBackend(b) }
+
 // NewClient generates a new client which all other clients should inherit from.
+// The client uses the globally defined Key and backend, this is the default instance
+// which is used by all of the package level functions.
 func NewClient() BaseClient { return &baseClient{B: getBackend(), key: Key} }
 
+// ProductionConfig returns a new default configuration for the production environment.
+func ProductionConfig() *BackendConfig { return &BackendConfig{URL: apiURL, oAuthURL: oAuthURL} }
+
+// StagingConfig returns a new default configuration for the staging environment.
+func StagingConfig() *BackendConfig {
+	return &BackendConfig{URL: stagingURL, oAuthURL: stagingOAuthURL}
+}
+
+// Client is an instance-scoped client, unlike the default instance which is generated
+// from the global Key and backend, a Client carries its own key, configuration and HTTP client.
+// This allows us to communicate with multiple environments or on behalf of multiple OAuth
+// applications in the same process.
+//
+// A Client implements BaseClient so can be supplied to the NewClient function
+// in any of the sub-packages.
+type Client struct{ *baseClient }
+
+// New generates a new Client using the supplied key (client_id) and config.
+// If no config is supplied, the default production configuration is used.
+func New(key string, config *BackendConfig) *Client { return NewWithBackend(key, NewBackend(config)) }
+
+// NewWithBackend generates a new Client using the supplied key (client_id) which performs
+// every call using the supplied backend.
+func NewWithBackend(key string, b Backend) *Client { return &Client{&baseClient{B: b, key: key}} }
+
+// NewBackend generates the default Backend, which performs HTTP requests against the trakt API,
+// using the supplied config. If no config is supplied, the default production configuration is used.
+// This can be used to decorate the default backend with a custom Backend.
+func NewBackend(config *BackendConfig) Backend {
+	if config == nil {
+		config = ProductionConfig()
+	}
+
+	return getBackendWithConfig(config)
+}
+
 // BackendConfig is used to configure a new Trakt backend.
 type BackendConfig struct {
 	// client is an HTTP client instance to use when making API requests.
@@ -105,13 +150,85 @@ type BackendConfig struct {
 	// warnings, and informational messages.
 	LeveledLogger LeveledLoggerInterface
 
+	// Logger is a structured logger which the backend will use to log errors,
+	// warnings, and informational messages along with fields describing the request
+	// such as the method, path, status, duration, retry and request ID.
+	//
+	// Sensitive values such as access tokens, refresh tokens and client secrets are
+	// always redacted before being logged. If left unset, messages are logged to the
+	// LeveledLogger.
+	Logger StructuredLoggerInterface
+
 	// maxNetworkRetries sets maximum number of times that the library will
 	// retry requests that appear to have failed due to an intermittent
-	// problem.
+	// problem. Rate limited requests are retried separately, see DefaultRetryPolicy.
 	//
 	// Defaults to 0.
 	MaxNetworkRetries int
 
+	// RetryPolicy determines if a failed request should be retried and how long
+	// to wait before retrying.
+	//
+	// Defaults to a DefaultRetryPolicy using MaxNetworkRetries.
+	RetryPolicy RetryPolicy
+
+	// GetRateLimit is the budget of GET requests which can be performed against the API.
+	// Requests are throttled so that this budget is never exceeded.
+	//
+	// Defaults to DefaultGetRateLimit, use NoRateLimit to disable throttling.
+	GetRateLimit *RateLimit
+
+	// WriteRateLimit is the budget of POST, PUT and DELETE requests which can be performed
+	// against the API. This is tracked separately from the GET budget.
+	//
+	// Defaults to DefaultWriteRateLimit, use NoRateLimit to disable throttling.
+	WriteRateLimit *RateLimit
+
+	// Cache is used to store the responses from GET requests. Cached responses are
+	// revalidated using their ETag once they expire and are served if the network fails.
+	// The Cache-Control header of the response is respected, responses marked as private
+	// are only stored in an in-memory cache, see NewMemoryCache.
+	//
+	// If left unset, responses are not cached.
+	Cache Cache
+
+	// CacheTTL is the amount of time a cached response is considered fresh if the
+	// response does not define its own max-age.
+	//
+	// Defaults to 5 minutes.
+	CacheTTL time.Duration
+
+	// Middleware is an ordered set of middleware which every request is passed through.
+	// The first middleware is the outermost, seeing the request first and the response last.
+	Middleware []Middleware
+
+	// Observer receives events for every call to the API which can be used to record
+	// metrics or trace calls, see ExpvarObserver and NewTracingObserver.
+	//
+	// If left unset, events are discarded.
+	Observer Observer
+
+	// CircuitBreaker configures a circuit breaker which fails requests fast while
+	// trakt is failing, the state changes are logged and supplied to the Observer
+	// if it implements CircuitObserver.
+	//
+	// If left unset, no circuit breaker is used.
+	CircuitBreaker *CircuitBreaker
+
+	// CoalesceRequests deduplicates concurrent identical GET requests. Requests with the same
+	// method, URL (including the extended level), client id and OAuth token share a single
+	// round-trip to trakt, each caller decodes its own copy of the response. Requests with
+	// custom headers set on their params are never coalesced, as the headers may change the response.
+	//
+	// Defaults to false.
+	CoalesceRequests bool
+
+	// DryRun builds every write request (POST, PUT or DELETE) without sending it, the call
+	// returns a *DryRunError containing the request instead. Read requests are still performed.
+	//
+	// Defaults to false.
+	DryRun bool
+
 	// URL is the base URL to use for API paths.
 	URL string
 
@@ -123,19 +240,38 @@ type BackendConfig struct {
 // headerUnmarshaller interface which is used to inform the unmarshaller
 // that we need to unmarshal this type using the response headers.
 type headerUnmarshaller interface {
-	// unmarshalHeaders allows us to unmarshal using data supplied in the
-	// response errors.
-	unmarshalHeaders(h http.Header) error
+	// UnmarshalHeaders allows us to unmarshal using data supplied in the
+	// response headers.
+	UnmarshalHeaders(h http.Header) error
+}
+
+// Frame is a single page of a paginated result which a Backend populates. The response body,
+// a JSON array, is unmarshalled into the receiver and the pagination metadata is populated
+// from the X-Pagination-* response headers.
+type Frame interface {
+	// Receiver returns the receiver which the response body is unmarshalled into.
+	Receiver() interface{}
+	// UnmarshalHeaders populates the pagination metadata from the response headers.
+	UnmarshalHeaders(h http.Header) error
 }
 
-// backend is an interface for making calls against a trakt service.
-type backend interface {
-	// call performs a call to path using the defined HTTP method and unmarshalling the result into v.
-	call(method, path, key string, params ParamsContainer, v interface{}) error
-	// callWithFrame performs a call using the defined HTTP method and unmarshalling into the supplied iterationFrame.
-	callWithFrame(method, path, key string, params ParamsContainer, v iterationFrame) error
-	// oAuthURL returns the
-	oAuthURL() string
+// Backend is an interface for making calls against a trakt service. The default implementation
+// performs HTTP requests against the trakt API, see NewBackend. A custom Backend can be used to
+// implement in-process fakes or to decorate another Backend, i.e to add auditing or to route
+// requests between regions. A Backend is wrapped into a client using NewWithBackend. The values
+// of the params supplied to a Backend are read using ParamsContext, ParamsHeaders, ParamsOAuth
+// and ParamsPagination.
+//
+// Implementations must be safe to use across multiple go-routines.
+type Backend interface {
+	// Call performs a call to path using the defined HTTP method and unmarshalling the result into v.
+	// key is the client_id of the application performing the call.
+	Call(method, path, key string, params ParamsContainer, v interface{}) error
+	// CallWithFrame performs a call to path using the defined HTTP method and unmarshalling the
+	// result into the supplied frame.
+	CallWithFrame(method, path, key string, params ParamsContainer, f Frame) error
+	// OAuthURL returns the base URL to use for OAuth paths.
+	OAuthURL() string
 }
 
 // BaseClient the base implementation of a client.
@@ -178,42 +314,127 @@ type BaseClient interface {
 	OAuthURL() string
 }
 
+// PathClient is a BaseClient which can also perform calls using a Path, which supplies the template
+// the path was formatted from to any middleware and observer, i.e as a low cardinality label. The
+// clients returned by NewClient, New and NewWithBackend implement PathClient, Paths can be used to
+// retrieve a PathClient from any BaseClient.
+type PathClient interface {
+	BaseClient
+
+	// NewIteratorPath is the same as NewIterator using a Path.
+	NewIteratorPath(method string, path Path, p ListParamsContainer) Iterator
+
+	// NewIteratorWithConditionPath is the same as NewIteratorWithCondition using a Path.
+	NewIteratorWithConditionPath(method string, path Path, p ListParamsContainer, cnd Condition) Iterator
+
+	// NewSimulatedIteratorPath is the same as NewSimulatedIterator using a Path.
+	NewSimulatedIteratorPath(method string, path Path, p ListParamsContainer) Iterator
+
+	// NewSimulatedIteratorWithConditionPath is the same as NewSimulatedIteratorWithCondition using a Path.
+	NewSimulatedIteratorWithConditionPath(method string, path Path, p ListParamsContainer, cnd Condition) Iterator
+
+	// CallPath is the same as Call using a Path.
+	CallPath(method string, path Path, params ParamsContainer, v interface{}) error
+}
+
+// Paths returns the supplied client as a PathClient. If the client does not implement PathClient,
+// calls are performed using the formatted path, so the template is not available.
+func Paths(c BaseClient) PathClient {
+	if p, ok := c.(PathClient); ok {
+		return p
+	}
+
+	return &pathClient{c}
+}
+
+// pathClient performs the calls of a PathClient using a BaseClient which does not implement it.
+type pathClient struct{ BaseClient }
+
+// NewIteratorPath implements PathClient interface.
+func (c *pathClient) NewIteratorPath(method string, path Path, p ListParamsContainer) Iterator {
+	return c.NewIterator(method, path.Path, p)
+}
+
+// NewIteratorWithConditionPath implements PathClient interface.
+func (c *pathClient) NewIteratorWithConditionPath(method string, path Path, p ListParamsContainer, cnd Condition) Iterator {
+	return c.NewIteratorWithCondition(method, path.Path, p, cnd)
+}
+
+// NewSimulatedIteratorPath implements PathClient interface.
+func (c *pathClient) NewSimulatedIteratorPath(method string, path Path, p ListParamsContainer) Iterator {
+	return c.NewSimulatedIterator(method, path.Path, p)
+}
+
+// NewSimulatedIteratorWithConditionPath implements PathClient interface.
+func (c *pathClient) NewSimulatedIteratorWithConditionPath(
+	method string, path Path, p ListParamsContainer, cnd Condition,
+) Iterator {
+	return c.NewSimulatedIteratorWithCondition(method, path.Path, p, cnd)
+}
+
+// CallPath implements PathClient interface.
+func (c *pathClient) CallPath(method string, path Path, params ParamsContainer, v interface{}) error {
+	return c.Call(method, path.Path, params, v)
+}
+
 // backends are the currently supported endpoints.
 type backends struct {
 	sync.RWMutex
-	API backend
+	API Backend
 }
 
 // baseClient a base client which gives us default functionality to parent client implementations.
 type baseClient struct {
 	// B the backend to query, this is an interface by design
 	// it allows us to override the underlined HTTP backend for tests etc
-	B backend
+	B Backend
 	// key the API (client_id) to use in requests, this is inherited from the var key
 	key string
 }
 
 // oAuthURL returns the defined oAuthURL for the configuration.
-func (b *baseClient) OAuthURL() string { return b.B.oAuthURL() }
+func (b *baseClient) OAuthURL() string { return b.B.OAuthURL() }
 
 // NewIterator implements BaseClient interface.
 func (b *baseClient) NewIterator(method, path string, p ListParamsContainer) Iterator {
-	return b.newIteratorWithReceiver(newIterator, method, path, p, defaultCondition, false)
+	return b.NewIteratorPath(method, rawPath(path), p)
 }
 
 // NewIteratorWithCondition implements BaseClient interface.
 func (b *baseClient) NewIteratorWithCondition(method, path string, p ListParamsContainer, cnd Condition) Iterator {
-	return b.newIteratorWithReceiver(newIterator, method, path, p, cnd, false)
+	return b.NewIteratorWithConditionPath(method, rawPath(path), p, cnd)
 }
 
 // NewSimulatedIterator implements BaseClient interface.
 func (b *baseClient) NewSimulatedIterator(method, path string, p ListParamsContainer) Iterator {
-	return b.newIteratorWithReceiver(newSimulatedIterator, method, path, p, defaultCondition, false)
+	return b.NewSimulatedIteratorPath(method, rawPath(path), p)
 }
 
 // NewIteratorWithCondition implements BaseClient interface.
 func (b *baseClient) NewSimulatedIteratorWithCondition(method, path string, p ListParamsContainer, cnd Condition) Iterator {
-	return b.newIteratorWithReceiver(newSimulatedIterator, method, path, p, cnd, false)
+	return b.NewSimulatedIteratorWithConditionPath(method, rawPath(path), p, cnd)
+}
+
+// NewIteratorPath implements PathClient interface.
+func (b *baseClient) NewIteratorPath(method string, path Path, p ListParamsContainer) Iterator {
+	return b.newIteratorWithReceiver(newIterator, method, path, p, defaultCondition)
+}
+
+// NewIteratorWithConditionPath implements PathClient interface.
+func (b *baseClient) NewIteratorWithConditionPath(method string, path Path, p ListParamsContainer, cnd Condition) Iterator {
+	return b.newIteratorWithReceiver(newIterator, method, path, p, cnd)
+}
+
+// NewSimulatedIteratorPath implements PathClient interface.
+func (b *baseClient) NewSimulatedIteratorPath(method string, path Path, p ListParamsContainer) Iterator {
+	return b.newIteratorWithReceiver(newSimulatedIterator, method, path, p, defaultCondition)
+}
+
+// NewSimulatedIteratorWithConditionPath implements PathClient interface.
+func (b *baseClient) NewSimulatedIteratorWithConditionPath(
+	method string, path Path, p ListParamsContainer, cnd Condition,
+) Iterator {
+	return b.newIteratorWithReceiver(newSimulatedIterator, method, path, p, cnd)
 }
 
 // Key implements BaseClient interface.
@@ -222,67 +443,92 @@ func (b *baseClient) Key() string { return b.key }
 // newIteratorWithReceiver helper function which performs all of the boilerplate code to generate an
 // iterator. Using this method over generating an iterator is far more efficient, as we can re-use the rcv pointer
 // for each frame, rather than allocate a new rcv for each frame processed.
+// The initial page is retrieved lazily if the params request it.
 func (b *baseClient) newIteratorWithReceiver(
-	generator iteratorFunc, method, path string, p ListParamsContainer, cnd Condition, lazyLoad bool,
+	generator iteratorFunc, method string, path Path, p ListParamsContainer, cnd Condition,
 ) Iterator {
-	return generator(p, func(i ListParamsContainer) (iterationFrame, error) {
+	return generator(p, endpoint{method: method, path: path.Path}, func(i ListParamsContainer) (iterationFrame, error) {
 		f := newEmptyFrame()
 		if cErr := cnd(); cErr != nil {
 			return f, cErr
 		}
 
-		err := b.B.callWithFrame(method, path, b.Key(), i, f)
+		if t, ok := b.B.(templatedBackend); ok {
+			return f, t.callRaw(method, path, b.Key(), i, f.Receiver(), f)
+		}
+
+		err := b.B.CallWithFrame(method, path.Path, b.Key(), i, f)
 		return f, err
-	}, lazyLoad)
+	}, p.lazy())
 }
 
 // Call helper function function which calls the underlined backend providing the assigned key.
 func (b *baseClient) Call(method, path string, params ParamsContainer, v interface{}) error {
-	return b.B.call(method, path, b.Key(), params, v)
+	return b.CallPath(method, rawPath(path), params, v)
+}
+
+// CallPath implements PathClient interface.
+func (b *baseClient) CallPath(method string, path Path, params ParamsContainer, v interface{}) error {
+	if t, ok := b.B.(templatedBackend); ok {
+		return t.callRaw(method, path, b.Key(), params, v, nil)
+	}
+
+	return b.B.Call(method, path.Path, b.Key(), params, v)
+}
+
+// templatedBackend is implemented by backends which use the template of the path, the template
+// is supplied to any middleware and observer.
+type templatedBackend interface {
+	callRaw(method string, path Path, key string, params ParamsContainer, v, h interface{}) error
 }
 
 // backendImplementation is the internal implementation for making HTTP calls
 // to Trakt.
 type backendImplementation struct {
-	URL               string
-	authURL           string
-	client            *http.Client
-	leveledLogger     LeveledLoggerInterface
-	maxNetworkRetries int
-
-	// networkRetriesSleep indicates whether the backend should use the normal
-	// sleep between retries.
-	//
-	// See also SetNetworkRetriesSleep.
-	networkRetriesSleep bool
+	URL         string
+	authURL     string
+	client      *http.Client
+	logger      StructuredLoggerInterface
+	retryPolicy RetryPolicy
+	limiter     *limiter
+	cache       Cache
+	cacheTTL    time.Duration
+	middleware  []Middleware
+	observer    Observer
+	breaker     *breaker
+	coalescer   *coalescer
+	dryRun      bool
 }
 
-// oAuthURL returns the oAuthURL for the backend.
-func (s *backendImplementation) oAuthURL() string { return s.authURL }
+// OAuthURL implements Backend interface.
+func (s *backendImplementation) OAuthURL() string { return s.authURL }
 
-// callWithFrame called for pagination type functions where we are typically querying for a single frame / segment
-// in the entire set. This puts a higher level of abstraction above that of the basic call function.
-func (s *backendImplementation) callWithFrame(method, path, key string, params ParamsContainer, v iterationFrame) error {
-	if v == nil {
+// CallWithFrame implements Backend interface. called for pagination type functions where we are typically querying
+// for a single frame / segment in the entire set. This puts a higher level of abstraction above that of the basic
+// call function.
+func (s *backendImplementation) CallWithFrame(method, path, key string, params ParamsContainer, f Frame) error {
+	if f == nil {
 		return &Error{Resource: path, Code: ErrorCodeEmptyFrameData, Body: "frame data is required"}
 	}
 
-	return s.callRaw(method, path, key, params, v.rcv(), v)
+	return s.callRaw(method, rawPath(path), key, params, f.Receiver(), f)
 }
 
-// call implements the backend interface. performs a call to the trakt API.
-func (s *backendImplementation) call(method, path, key string, params ParamsContainer, v interface{}) error {
-	return s.callRaw(method, path, key, params, v, nil)
+// Call implements Backend interface. performs a call to the trakt API.
+func (s *backendImplementation) Call(method, path, key string, params ParamsContainer, v interface{}) error {
+	return s.callRaw(method, rawPath(path), key, params, v, nil)
 }
 
 // callRaw executes a HTTP request to the trakt API by generating the path, body and executing the request and
 // unmarshalling the response into v.
-func (s *backendImplementation) callRaw(method, path, key string, params ParamsContainer, v, h interface{}) error {
+func (s *backendImplementation) callRaw(method string, p Path, key string, params ParamsContainer, v, h interface{}) error {
 	rv := reflect.ValueOf(params)
 	if !rv.IsValid() || rv.IsNil() {
 		params = &BasicParams{}
 	}
 
+	path := p.Path
+
 	var body []byte
 	if isHTTPWriteMethod(method) {
 		var encodeErr error
@@ -301,15 +547,17 @@ func (s *backendImplementation) callRaw(method, path, key string, params ParamsC
 		path += `?` + uv.Encode()
 	}
 
-	bodyBuffer := bytes.NewBuffer(body)
-
 	req, err := s.newRequest(method, path, key, applicationTypeJSON, params)
 	if err != nil {
 		return err
 	}
 
-	// perform the request.
-	if err := s.do(req, bodyBuffer, params, v, h); err != nil {
+	// perform the request, passing it through any defined middleware.
+	handler := chain(s.handle(h), s.middleware)
+	r := &Request{
+		HTTPRequest: req, Params: params, Body: body, PathTemplate: p.Template, DryRun: s.dryRun || isDryRun(params),
+	}
+	if err := handler(r, v); err != nil {
 		return err
 	}
 
@@ -326,7 +574,7 @@ func (s *backendImplementation) newRequest(method, path, key, contentType string
 	path = s.URL + path
 	req, err := http.NewRequest(method, path, nil)
 	if err != nil {
-		s.leveledLogger.Errorf("Cannot create request: %v", err)
+		s.logger.Error("Cannot create request", F(FieldMethod, method), F(FieldPath, path), F(FieldError, err))
 		return nil, err
 	}
 
@@ -335,7 +583,7 @@ func (s *backendImplementation) newRequest(method, path, key, contentType string
 
 	// add trakt specific headers.
 	req.Header.Add("trakt-api-version", APIVersion)
-	req.Header.Add("trakt-api-key", key)
+	req.Header.Add(apiKeyHeader, key)
 
 	// add oauth token if supplied.
 	token := params.oauth()
@@ -360,14 +608,77 @@ func (s *backendImplementation) newRequest(method, path, key, contentType string
 // do is used by call / callWithFrame to execute an API request and parse the response. It uses
 // the backend's HTTP client to execute the request and unmarshals the response
 // into v. It also handles unmarshaling errors returned by the API.
-func (s *backendImplementation) do(req *http.Request, body *bytes.Buffer, params ParamsContainer, v, h interface{}) error {
-	s.leveledLogger.Infof("Requesting %v %v%v\n", req.Method, req.URL.Host, req.URL.Path)
+// The HTTP response is returned if the request reached trakt.
+func (s *backendImplementation) do(
+	info *RequestInfo, req *http.Request, body *bytes.Buffer, params ParamsContainer, v, h interface{},
+) (res *http.Response, err error) {
+	s.logger.Info("Requesting", requestFields(req)...)
+
+	out := &ResponseInfo{}
+	s.observer.RequestStarted(info)
+	defer func() {
+		if res != nil {
+			out.StatusCode = res.StatusCode
+		}
+
+		out.Duration, out.Err = time.Since(info.StartedAt), err
+		s.observer.ResponseReceived(info, out)
+
+		if m := responseMetadataFor(params); m != nil {
+			m.populate(res, out)
+		}
+	}()
 
-	var res *http.Response
-	var err error
-	var requestDuration time.Duration
 	var resBody []byte
-	for retry := 0; ; {
+	if s.coalescer != nil && req.Method == http.MethodGet && len(params.headers()) == 0 {
+		res, resBody, err = s.coalescedRoundTrip(info, out, req, params)
+	} else {
+		res, resBody, err = s.roundTrip(info, out, req, body, params)
+	}
+
+	if err != nil {
+		return res, err
+	}
+
+	s.logger.Debug("Response", append(requestFields(req), F(FieldBody, resBody))...)
+
+	if v != nil {
+		return res, s.decode(info, res, resBody, v, h)
+	}
+
+	return res, nil
+}
+
+// roundTrip performs the request, returning the response and its body. The response is served
+// from the cache if possible, otherwise the request is performed, retrying any failures which
+// the retry policy allows.
+func (s *backendImplementation) roundTrip(
+	info *RequestInfo, out *ResponseInfo, req *http.Request, body *bytes.Buffer, params ParamsContainer,
+) (res *http.Response, resBody []byte, err error) {
+	// attempt to serve the request from the cache.
+	key, entry := s.cacheLookup(req)
+	if entry != nil && entry.Fresh(time.Now()) {
+		s.logger.Info("Serving cached response", requestFields(req)...)
+		out.Cached = true
+		return entry.response(req), entry.Body, nil
+	}
+
+	var requestDuration time.Duration
+	for retry, rateLimited := 0, 0; ; {
+		// wait until we are within the rate limit budget for the request.
+		if err = s.limiter.wait(params.context(), req.Method); err != nil {
+			s.logger.Error("Request cancelled waiting for rate limit", append(requestFields(req), F(FieldError, err))...)
+			return nil, nil, err
+		}
+
+		// fail fast without performing the request while trakt is failing.
+		generation, allowed, wait := s.breaker.allow(time.Now())
+		if !allowed {
+			res, err = nil, newCircuitOpenError(req.URL.Path, wait)
+			s.logger.Warn("Circuit breaker is open", append(requestFields(req), F(FieldRetryAfter, wait))...)
+			break
+		}
+
 		start := time.Now()
 
 		if body != nil {
@@ -383,7 +694,16 @@ func (s *backendImplementation) do(req *http.Request, body *bytes.Buffer, params
 		res, err = s.client.Do(req)
 
 		requestDuration = time.Since(start)
-		s.leveledLogger.Infof("Request completed in %v (retry: %v)", requestDuration, retry)
+		info.received(res)
+		fields := append(requestFields(req), F(FieldDuration, requestDuration), F(FieldRetry, retry))
+		if res != nil {
+			fields = append(fields, F(FieldStatus, res.StatusCode))
+			if id := res.Header.Get(requestIDHeader); id != "" {
+				fields = append(fields, F(FieldRequestID, id))
+			}
+		}
+
+		s.logger.Info("Request completed", fields...)
 
 		if err == nil {
 			resBody, err = ioutil.ReadAll(res.Body)
@@ -391,37 +711,85 @@ func (s *backendImplementation) do(req *http.Request, body *bytes.Buffer, params
 		}
 
 		if err != nil {
-			s.leveledLogger.Errorf("Request failed with error: %v", err)
+			s.logger.Error("Request failed", append(fields, F(FieldError, err))...)
+			err = newNetworkError(req.URL.Path, err)
 		} else if res.StatusCode >= 400 {
 			err = s.responseToError(res, resBody, params)
 		}
 
+		s.breaker.done(req.Context(), generation, err, time.Now())
+
+		// if we have exceeded the rate limit, block any further requests
+		// until the time trakt has told us to wait.
+		var rateLimitDelay time.Duration
+		var blocked bool
+		if res != nil && res.StatusCode == http.StatusTooManyRequests {
+			rateLimitDelay = retryAfter(res.Header)
+			blocked = s.limiter.block(req.Method, rateLimitDelay)
+			s.logger.Warn("Rate limit exceeded", append(fields, F(FieldRetryAfter, rateLimitDelay))...)
+		}
+
 		// If the response was okay, or an error that shouldn't be retried,
 		// we're done, and it's safe to leave the retry loop.
-		if !s.shouldRetry(err, req, res, retry) {
+		if err == nil {
+			break
+		}
+
+		attempt := &RetryAttempt{Err: err, Method: req.Method, Attempt: retry - rateLimited, RateLimitAttempt: rateLimited}
+		if res != nil {
+			attempt.StatusCode, attempt.Header = res.StatusCode, res.Header
+		}
+
+		// writes are only retried if the caller has opted in, as a retried write could
+		// be applied twice. A rate limited write was never applied so is always safe to retry.
+		if isHTTPWriteMethod(req.Method) && !canRetryWrite(params) && attempt.StatusCode != http.StatusTooManyRequests {
+			break
+		}
+
+		shouldRetry, sleepDuration := s.retryPolicy.Retry(attempt)
+		if !shouldRetry {
 			break
 		}
 
-		sleepDuration := s.sleepTime(retry)
+		// the limiter only waits for the rate limit to reset if the method is rate limited,
+		// otherwise we wait here for the time trakt has told us to wait.
+		if !blocked && sleepDuration < rateLimitDelay {
+			sleepDuration = rateLimitDelay
+		}
 		retry++
+		if attempt.StatusCode == http.StatusTooManyRequests {
+			rateLimited++
+		}
 
-		s.leveledLogger.Warnf("Initiating retry %v for request %v %v%v after sleeping %v",
-			retry, req.Method, req.URL.Host, req.URL.Path, sleepDuration)
+		out.Retries = retry
+		s.observer.RequestRetried(info, attempt, sleepDuration)
 
-		time.Sleep(sleepDuration)
-	}
+		s.logger.Warn("Initiating retry", append(requestFields(req), F(FieldRetry, retry), F(FieldRetryAfter, sleepDuration))...)
 
-	if err != nil {
-		return err
+		// abort waiting as soon as the request context is done.
+		if sErr := sleep(params.context(), sleepDuration); sErr != nil {
+			s.logger.Error("Request cancelled waiting to retry", append(requestFields(req), F(FieldError, sErr))...)
+			return res, nil, sErr
+		}
 	}
 
-	s.leveledLogger.Debugf("Response: %s\n", string(resBody))
+	return s.cacheStore(key, entry, req, res, resBody, err)
+}
 
-	if v != nil {
-		return s.unmarshalJSONVerbose(res, resBody, v, h)
+// decode unmarshals the response body into v, notifying the observer if the
+// response could not be decoded.
+func (s *backendImplementation) decode(info *RequestInfo, res *http.Response, body []byte, v, h interface{}) error {
+	err := s.unmarshalJSONVerbose(res, body, v, h)
+	if err != nil {
+		s.observer.DecodeFailed(info, err)
 	}
 
-	return nil
+	return err
+}
+
+// requestFields generates the fields which describe the request when logging.
+func requestFields(req *http.Request) []Field {
+	return []Field{F(FieldMethod, req.Method), F(FieldPath, req.URL.Path)}
 }
 
 // responseToError converts a trakt HTTP status code response to an error.
@@ -432,12 +800,20 @@ func (s *backendImplementation) responseToError(res *http.Response, resBody []by
 		errorHandler = e
 	}
 
+	// trakt supplies an error and description in the body for some errors.
+	var eb errorBody
+	_ = json.Unmarshal(resBody, &eb)
+
 	return &Error{
 		HTTPStatusCode: res.StatusCode,
 		RequestID:      res.Header.Get(requestIDHeader),
 		Body:           string(resBody),
 		Resource:       res.Request.URL.Path,
 		Code:           errorHandler.Code(res.StatusCode),
+		Message:        eb.Error,
+		Description:    eb.Description,
+		RetryAfter:     retryAfter(res.Header),
+		Header:         res.Header,
 	}
 }
 
@@ -457,7 +833,7 @@ func (s *backendImplementation) unmarshalJSONVerbose(res *http.Response, body []
 		newErr := s.responseToError(res, []byte(strings.Replace(bodySample, "\n", "\\n", -1)), nil)
 		newErr.(*Error).Code = ErrorCodeEncodingError
 
-		s.leveledLogger.Errorf("%s", newErr)
+		s.logger.Error("Cannot decode response", F(FieldPath, res.Request.URL.Path), F(FieldError, newErr))
 		return newErr
 	}
 
@@ -467,7 +843,7 @@ func (s *backendImplementation) unmarshalJSONVerbose(res *http.Response, body []
 	// not just provided in the response body.
 	err = unmarshalHeaders(res, con)
 	if err != nil {
-		s.leveledLogger.Errorf("%s", err)
+		s.logger.Error("Cannot decode response headers", F(FieldPath, res.Request.URL.Path), F(FieldError, err))
 		return err
 	}
 
@@ -479,93 +855,11 @@ func (s *backendImplementation) unmarshalJSONVerbose(res *http.Response, body []
 func unmarshalHeaders(res *http.Response, i interface{}) error {
 	switch m := i.(type) {
 	case headerUnmarshaller:
-		return m.unmarshalHeaders(res.Header)
+		return m.UnmarshalHeaders(res.Header)
 	}
 	return nil
 }
 
-// Checks if an error is a problem that we should retry on. This includes both
-// socket errors that may represent an intermittent problem and some special
-// HTTP statuses.
-func (s *backendImplementation) shouldRetry(err error, req *http.Request, resp *http.Response, numRetries int) bool {
-	if numRetries >= s.maxNetworkRetries {
-		return false
-	}
-
-	traktErr, _ := err.(*Error)
-
-	if traktErr == nil && err != nil {
-		return true
-	}
-
-	// 409 Conflict
-	if resp.StatusCode == http.StatusConflict {
-		return true
-	}
-
-	// 429 Too Many Requests
-	//
-	// There are a few different problems that can lead to a 429. The most
-	// common is rate limiting, on which we *don't* want to retry because
-	// that'd likely contribute to more contention problems. However, some 429s
-	// are lock timeouts, which is when a request conflicted with another
-	// request or an internal process on some particular object. These 429s are
-	// safe to retry.
-	if resp.StatusCode == http.StatusTooManyRequests {
-		return false
-	}
-
-	// 500 Internal Server Error
-	if resp.StatusCode >= http.StatusInternalServerError {
-		return true
-	}
-
-	// 503 Service Unavailable
-	if resp.StatusCode == http.StatusServiceUnavailable {
-		return true
-	}
-
-	// 503 Gateway Timeout
-	if resp.StatusCode == http.StatusGatewayTimeout {
-		return true
-	}
-
-	// if we have a cloudflare error (which dont use standard HTTP status codes)
-	if isCloudflareError(resp.StatusCode) {
-		return true
-	}
-
-	return false
-}
-
-// sleepTime calculates sleeping/delay time in milliseconds between failure and a new one request.
-func (s *backendImplementation) sleepTime(numRetries int) time.Duration {
-	// We disable sleeping in some cases for tests.
-	if !s.networkRetriesSleep {
-		return 0 * time.Second
-	}
-
-	// Apply exponential backoff with minNetworkRetriesDelay on the
-	// number of num_retries so far as inputs.
-	delay := minNetworkRetriesDelay + minNetworkRetriesDelay*time.Duration(numRetries*numRetries)
-
-	// Do not allow the number to exceed maxNetworkRetriesDelay.
-	if delay > maxNetworkRetriesDelay {
-		delay = maxNetworkRetriesDelay
-	}
-
-	// Apply some jitter by randomizing the value in the range of 75%-100%.
-	jitter := rand.Int63n(int64(delay / 4))
-	delay -= time.Duration(jitter)
-
-	// But never sleep less than the base sleep seconds.
-	if delay < minNetworkRetriesDelay {
-		delay = minNetworkRetriesDelay
-	}
-
-	return delay
-}
-
 // FormatURLPath takes a format string (of the kind used in the fmt package)
 // representing a URL path with a number of parameters that belong in the path
 // and returns a formatted string.
@@ -592,6 +886,32 @@ func FormatURLPath(format string, params ...interface{}) string {
 	return fmt.Sprintf(format, untypedParams[:i]...)
 }
 
+// Path is the path of a resource along with the template it was formatted from.
+type Path struct {
+	// Template the template the path was formatted from, i.e /shows/%s/seasons. This has a low
+	// cardinality so is suitable as a label.
+	Template string
+	// Path the formatted path, i.e /shows/the-office/seasons.
+	Path string
+}
+
+// NewPath generates a Path by formatting the template with the supplied parameters, see FormatURLPath.
+func NewPath(template string, params ...interface{}) Path {
+	p := rawPath(template)
+	p.Path = FormatURLPath(template, params...)
+	return p
+}
+
+// rawPath generates a Path for a path which was not formatted, the path is used as the template.
+func rawPath(path string) Path {
+	tpl := path
+	if !strings.HasPrefix(tpl, "/") {
+		tpl = "/" + tpl
+	}
+
+	return Path{Template: tpl, Path: path}
+}
+
 // formatInterface attempts to format an interface into a string.
 func formatInterface(i interface{}) (string, error) {
 	if i == nil {
@@ -673,7 +993,7 @@ func formatSlice(slice interface{}) string {
 }
 
 // getBackend retrieves the API backend.
-func getBackend() backend {
+func getBackend() Backend {
 	supportedBackends.RLock()
 	defer supportedBackends.RUnlock()
 
@@ -683,7 +1003,7 @@ func getBackend() backend {
 // getBackendWithConfig is the same as getBackend except that it can be given a
 // configuration struct that will configure certain aspects of the backend
 // that's return.
-func getBackendWithConfig(config *BackendConfig) backend {
+func getBackendWithConfig(config *BackendConfig) Backend {
 	if config.HTTPClient == nil {
 		config.HTTPClient = httpClient
 	}
@@ -696,6 +1016,26 @@ func getBackendWithConfig(config *BackendConfig) backend {
 		config.URL = apiURL
 	}
 
+	if config.GetRateLimit == nil {
+		config.GetRateLimit = DefaultGetRateLimit
+	}
+
+	if config.WriteRateLimit == nil {
+		config.WriteRateLimit = DefaultWriteRateLimit
+	}
+
+	if config.RetryPolicy == nil {
+		config.RetryPolicy = &DefaultRetryPolicy{MaxRetries: config.MaxNetworkRetries}
+	}
+
+	if config.Observer == nil {
+		config.Observer = nopObserver{}
+	}
+
+	if config.CacheTTL <= 0 {
+		config.CacheTTL = defaultCacheTTL
+	}
+
 	if config.oAuthURL == "" {
 		config.oAuthURL = oAuthURL
 	}
@@ -707,7 +1047,7 @@ func getBackendWithConfig(config *BackendConfig) backend {
 }
 
 // setBackend sets the backend used in the binding.
-func setBackend(b backend) {
+func setBackend(b Backend) {
 	supportedBackends.Lock()
 	defer supportedBackends.Unlock()
 	supportedBackends.API = b
@@ -729,13 +1069,44 @@ func (nopReadCloser) Close() error { return nil }
 //
 // The vast majority of the time you should be calling getBackendWithConfig
 // instead of this function.
-func newBackendImplementation(config *BackendConfig) backend {
-	return &backendImplementation{
-		client:              config.HTTPClient,
-		leveledLogger:       config.LeveledLogger,
-		maxNetworkRetries:   config.MaxNetworkRetries,
-		URL:                 config.URL,
-		networkRetriesSleep: true,
+func newBackendImplementation(config *BackendConfig) Backend {
+	s := &backendImplementation{
+		client:      config.HTTPClient,
+		logger:      newBackendLogger(config),
+		retryPolicy: config.RetryPolicy,
+		limiter:     newLimiter(config.GetRateLimit, config.WriteRateLimit),
+		cache:       config.Cache,
+		cacheTTL:    config.CacheTTL,
+		middleware:  config.Middleware,
+		observer:    config.Observer,
+		dryRun:      config.DryRun,
+		URL:         config.URL,
+		authURL:     config.oAuthURL,
+	}
+
+	s.breaker = newBreaker(config.CircuitBreaker, s.circuitStateChanged)
+	if config.CoalesceRequests {
+		s.coalescer = newCoalescer()
+	}
+
+	return s
+}
+
+// circuitStateChanged logs the change in state of the circuit breaker and notifies
+// the observer if it implements CircuitObserver.
+func (s *backendImplementation) circuitStateChanged(from, to CircuitState) {
+	fields := []Field{F(FieldCircuitFrom, from.String()), F(FieldCircuitTo, to.String())}
+	switch to {
+	case CircuitOpen:
+		s.logger.Error("Circuit breaker opened", fields...)
+	case CircuitHalfOpen:
+		s.logger.Warn("Circuit breaker half-opened", fields...)
+	default:
+		s.logger.Info("Circuit breaker closed", fields...)
+	}
+
+	if o, ok := s.observer.(CircuitObserver); ok {
+		o.CircuitStateChanged(from, to)
 	}
 }
 
@@ -0,0 +1,175 @@
+package trakttest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// calendar date and range values enforced by the API.
+const (
+	calendarDateFormat = "2006-01-02"
+	maxCalendarDays    = 33
+)
+
+// calendarRange parses the start date and number of days from the path,
+// writing an error response if they are invalid.
+func calendarRange(w http.ResponseWriter, r *request) (time.Time, time.Time, bool) {
+	start, err := time.Parse(calendarDateFormat, r.vars["start"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "invalid start date")
+		return time.Time{}, time.Time{}, false
+	}
+
+	days, err := strconv.Atoi(r.vars["days"])
+	if err != nil || days < 1 || days > maxCalendarDays {
+		writeError(w, http.StatusBadRequest, "invalid_request", "days must be between 1 and 33")
+		return time.Time{}, time.Time{}, false
+	}
+
+	return start, start.AddDate(0, 0, days), true
+}
+
+// inRange determines if t is in the range [start, end).
+func inRange(t, start, end time.Time) bool { return !t.IsZero() && !t.Before(start) && t.Before(end) }
+
+// calendarShows generates a handler for the show calendars. filter determines which episodes
+// are included, the my calendars only include shows the user has watched, collected or added
+// to their watchlist.
+func (s *Server) calendarShows(filter func(*Season, *Episode) bool) handler {
+	return func(w http.ResponseWriter, r *request) {
+		start, end, ok := calendarRange(w, r)
+		if !ok {
+			return
+		}
+
+		type entry struct {
+			show    *Show
+			season  *Season
+			episode *Episode
+		}
+
+		var entries []*entry
+		for _, sh := range s.shows {
+			if r.user != nil && !r.user.follows(sh) {
+				continue
+			}
+
+			for _, sn := range sh.Seasons {
+				for _, e := range sn.Episodes {
+					if inRange(e.FirstAired, start, end) && filter(sn, e) {
+						entries = append(entries, &entry{sh, sn, e})
+					}
+				}
+			}
+		}
+
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].episode.FirstAired.Before(entries[j].episode.FirstAired)
+		})
+
+		items := make([]interface{}, 0, len(entries))
+		for _, e := range entries {
+			items = append(items, map[string]interface{}{
+				"first_aired": formatTime(e.episode.FirstAired),
+				"episode":     episodeJSON(e.season, e.episode),
+				"show":        showJSON(e.show),
+			})
+		}
+
+		writeJSON(w, http.StatusOK, items)
+	}
+}
+
+// calendarMovies generates a handler for the movie calendars. release determines which date is used
+// for the movie, the my calendars only include movies the user has collected, watched or added
+// to their watchlist.
+func (s *Server) calendarMovies(release func(*Movie) time.Time) handler {
+	return func(w http.ResponseWriter, r *request) {
+		start, end, ok := calendarRange(w, r)
+		if !ok {
+			return
+		}
+
+		var movies []*Movie
+		for _, m := range s.movies {
+			if r.user != nil && !r.user.has(&item{typ: trakt.TypeMovie, movie: m}) {
+				continue
+			}
+
+			if inRange(release(m), start, end) {
+				movies = append(movies, m)
+			}
+		}
+
+		sort.SliceStable(movies, func(i, j int) bool { return release(movies[i]).Before(release(movies[j])) })
+
+		items := make([]interface{}, 0, len(movies))
+		for _, m := range movies {
+			items = append(items, map[string]interface{}{
+				"released": release(m).Format(calendarDateFormat),
+				"movie":    movieJSON(m),
+			})
+		}
+
+		writeJSON(w, http.StatusOK, items)
+	}
+}
+
+// follows determines if the user has any synced data for a show.
+func (u *user) follows(sh *Show) bool {
+	for _, i := range u.items() {
+		if i.typ != trakt.TypeMovie && i.show == sh {
+			return true
+		}
+	}
+
+	return false
+}
+
+// has determines if the user has any synced data for an item.
+func (u *user) has(it *item) bool {
+	for _, i := range u.items() {
+		if i.key() == it.key() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// items returns every item in the user's history, collection and watchlist.
+func (u *user) items() []*item {
+	var items []*item
+	for _, h := range u.history {
+		items = append(items, h.item)
+	}
+
+	for _, c := range u.collection {
+		items = append(items, c.item)
+	}
+
+	for _, l := range u.watchlist {
+		items = append(items, l.item)
+	}
+
+	return items
+}
+
+// allEpisodes includes every episode in the show calendars.
+func allEpisodes(*Season, *Episode) bool { return true }
+
+// newShows includes series premieres (season 1, episode 1) in the show calendars.
+func newShows(sn *Season, e *Episode) bool { return sn.Number == 1 && e.Number == 1 }
+
+// seasonPremieres includes season premieres (any season, episode 1) in the show calendars.
+func seasonPremieres(_ *Season, e *Episode) bool { return e.Number == 1 }
+
+// released the theatrical release date of a movie.
+func released(m *Movie) time.Time { return m.Released }
+
+// dvdReleased the DVD release date of a movie.
+func dvdReleased(m *Movie) time.Time { return m.DVDReleased }
@@ -0,0 +1,102 @@
+package trakttest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// getMovie handles GET /movies/:id.
+func (s *Server) getMovie(w http.ResponseWriter, r *request) {
+	it, ok := s.findByID(trakt.TypeMovie, r.vars["id"])
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "movie not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, movieJSON(it.movie))
+}
+
+// getShow handles GET /shows/:id.
+func (s *Server) getShow(w http.ResponseWriter, r *request) {
+	if it, ok := s.pathShow(w, r); ok {
+		writeJSON(w, http.StatusOK, showJSON(it.show))
+	}
+}
+
+// listSeasons handles GET /shows/:id/seasons, episodes are included
+// when the extended level is episodes.
+func (s *Server) listSeasons(w http.ResponseWriter, r *request) {
+	it, ok := s.pathShow(w, r)
+	if !ok {
+		return
+	}
+
+	episodes := strings.Contains(r.URL.Query().Get("extended"), string(trakt.ExtendedTypeEpisodes))
+	seasons := make([]interface{}, 0, len(it.show.Seasons))
+	for _, sn := range it.show.Seasons {
+		seasons = append(seasons, seasonJSON(sn, episodes))
+	}
+
+	writeJSON(w, http.StatusOK, seasons)
+}
+
+// getSeason handles GET /shows/:id/seasons/:season which lists the episodes in the season.
+func (s *Server) getSeason(w http.ResponseWriter, r *request) {
+	sn, ok := s.pathSeason(w, r)
+	if !ok {
+		return
+	}
+
+	episodes := make([]interface{}, 0, len(sn.season.Episodes))
+	for _, e := range sn.season.Episodes {
+		episodes = append(episodes, episodeJSON(sn.season, e))
+	}
+
+	writeJSON(w, http.StatusOK, episodes)
+}
+
+// getEpisode handles GET /shows/:id/seasons/:season/episodes/:episode.
+func (s *Server) getEpisode(w http.ResponseWriter, r *request) {
+	sn, ok := s.pathSeason(w, r)
+	if !ok {
+		return
+	}
+
+	n, _ := strconv.ParseInt(r.vars["episode"], 10, 64)
+	ep, ok := findEpisode(sn, sn.season.Number, n)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "episode not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, episodeJSON(ep.season, ep.episode))
+}
+
+// pathShow finds the show identified in the path, writing a not found error if it does not exist.
+func (s *Server) pathShow(w http.ResponseWriter, r *request) (*item, bool) {
+	it, ok := s.findByID(trakt.TypeShow, r.vars["id"])
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "show not found")
+	}
+
+	return it, ok
+}
+
+// pathSeason finds the season identified in the path, writing a not found error if it does not exist.
+func (s *Server) pathSeason(w http.ResponseWriter, r *request) (*item, bool) {
+	it, ok := s.pathShow(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	n, _ := strconv.ParseInt(r.vars["season"], 10, 64)
+	sn, ok := findSeasonNumber(it.show, n)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "season not found")
+	}
+
+	return sn, ok
+}
@@ -0,0 +1,138 @@
+package trakttest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// scrobbleThreshold the progress at which stopping a scrobble marks the item as watched.
+const scrobbleThreshold = 80
+
+// checkin an active checkin.
+type checkin struct {
+	id        int64
+	item      *item
+	watchedAt time.Time
+	expiresAt time.Time
+}
+
+// playbackBody the request body used by the checkin and scrobble endpoints.
+type playbackBody struct {
+	mediaBody
+
+	Progress float64                `json:"progress"`
+	Sharing  map[string]interface{} `json:"sharing"`
+}
+
+// decodePlaybackBody decodes the request body and finds the movie or episode it refers to,
+// writing an error response on failure.
+func (s *Server) decodePlaybackBody(w http.ResponseWriter, r *request) (*playbackBody, *item, bool) {
+	b := &playbackBody{}
+	if err := r.decode(b); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return nil, nil, false
+	}
+
+	it, ok := s.findItem(&b.mediaBody)
+	if !ok || (it.typ != trakt.TypeMovie && it.typ != trakt.TypeEpisode) {
+		writeError(w, http.StatusNotFound, "not_found", "movie or episode not found")
+		return nil, nil, false
+	}
+
+	if b.Sharing == nil {
+		b.Sharing = map[string]interface{}{"twitter": false, "tumblr": false, "medium": false}
+	}
+
+	return b, it, true
+}
+
+// startCheckin handles POST /checkin.
+func (s *Server) startCheckin(w http.ResponseWriter, r *request) {
+	b, it, ok := s.decodePlaybackBody(w, r)
+	if !ok {
+		return
+	}
+
+	if c := r.user.checkin; c != nil && r.now.Before(c.expiresAt) {
+		writeJSON(w, http.StatusConflict, map[string]interface{}{"expires_at": formatTime(c.expiresAt)})
+		return
+	}
+
+	h := s.watch(r.user, it, "checkin", r.now, r.now)
+	r.user.checkin = &checkin{id: h.id, item: it, watchedAt: r.now, expiresAt: r.now.Add(s.opts.CheckinDuration)}
+
+	writeJSON(w, http.StatusCreated, it.merge(map[string]interface{}{
+		"id":         h.id,
+		"watched_at": formatTime(r.now),
+		"sharing":    b.Sharing,
+	}))
+}
+
+// stopCheckin handles DELETE /checkin.
+func (s *Server) stopCheckin(w http.ResponseWriter, r *request) {
+	r.user.checkin = nil
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// scrobble handles POST /scrobble/:event.
+func (s *Server) scrobble(w http.ResponseWriter, r *request) {
+	event := r.vars["event"]
+	if event != "start" && event != "pause" && event != "stop" {
+		writeError(w, http.StatusNotFound, "not_found", "unknown scrobble event")
+		return
+	}
+
+	b, it, ok := s.decodePlaybackBody(w, r)
+	if !ok {
+		return
+	}
+
+	if b.Progress < 0 || b.Progress > 100 {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "progress must be between 0 and 100")
+		return
+	}
+
+	u, action, id := r.user, event, s.nextID()
+	switch {
+	case event == "stop" && b.Progress >= scrobbleThreshold:
+		last, ok := u.scrobbled[it.key()]
+		if expires := last.Add(s.opts.CheckinDuration); ok && r.now.Before(expires) {
+			writeJSON(w, http.StatusConflict, map[string]interface{}{
+				"watched_at": formatTime(last),
+				"expires_at": formatTime(expires),
+			})
+			return
+		}
+
+		u.removePlayback(it)
+		u.scrobbled[it.key()] = r.now
+		id, action = s.watch(u, it, "scrobble", r.now, r.now).id, "scrobble"
+	case event == "start":
+		u.removePlayback(it)
+	default:
+		// pausing or stopping before the item has been watched saves the progress.
+		u.removePlayback(it)
+		u.playback = append(u.playback, &playback{id: id, item: it, progress: b.Progress, pausedAt: r.now})
+		u.touch(r.now, it.group(), "paused_at")
+		action = "pause"
+	}
+
+	writeJSON(w, http.StatusCreated, it.merge(map[string]interface{}{
+		"id":       id,
+		"action":   action,
+		"progress": b.Progress,
+		"sharing":  b.Sharing,
+	}))
+}
+
+// removePlayback removes any saved progress for an item.
+func (u *user) removePlayback(i *item) {
+	for idx, p := range u.playback {
+		if p.item.key() == i.key() {
+			u.playback = append(u.playback[:idx], u.playback[idx+1:]...)
+			return
+		}
+	}
+}
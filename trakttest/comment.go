@@ -0,0 +1,356 @@
+package trakttest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// comment validation rules enforced by the API.
+const (
+	minCommentWords = 5
+	reviewWords     = 200
+)
+
+// comment a comment or reply.
+type comment struct {
+	id        int64
+	parent    int64
+	text      string
+	spoiler   bool
+	createdAt time.Time
+	updatedAt time.Time
+	user      *user
+	item      *item
+	replies   int
+	// likes the time each user liked the comment, keyed by their username.
+	likes map[string]time.Time
+}
+
+// json generates the representation of the comment.
+func (c *comment) json() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         c.id,
+		"parent_id":  c.parent,
+		"comment":    c.text,
+		"spoiler":    c.spoiler,
+		"review":     len(strings.Fields(c.text)) >= reviewWords,
+		"created_at": formatTime(c.createdAt),
+		"updated_at": formatTime(c.updatedAt),
+		"replies":    c.replies,
+		"likes":      len(c.likes),
+		"user":       c.user.json(),
+	}
+}
+
+// commentBody the request body used to post or update a comment.
+type commentBody struct {
+	mediaBody
+
+	Comment string `json:"comment"`
+	Spoiler bool   `json:"spoiler"`
+}
+
+// decodeCommentBody decodes the request body and validates the comment text,
+// writing an error response on failure.
+func decodeCommentBody(w http.ResponseWriter, r *request) (*commentBody, bool) {
+	b := &commentBody{}
+	if err := r.decode(b); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return nil, false
+	}
+
+	if len(strings.Fields(b.Comment)) < minCommentWords {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "comment must be at least 5 words")
+		return nil, false
+	}
+
+	return b, true
+}
+
+// findComment finds the comment identified in the path, writing a not found error if it does not exist.
+func (s *Server) findComment(w http.ResponseWriter, r *request) (*comment, bool) {
+	id, _ := strconv.ParseInt(r.vars["id"], 10, 64)
+	for _, c := range s.comments {
+		if c.id == id {
+			return c, true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "not_found", "comment not found")
+	return nil, false
+}
+
+// ownComment finds the comment identified in the path, ensuring it belongs to the authenticated user.
+func (s *Server) ownComment(w http.ResponseWriter, r *request) (*comment, bool) {
+	c, ok := s.findComment(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	if c.user != r.user {
+		writeError(w, http.StatusUnauthorized, "invalid_user", "the comment does not belong to the user")
+		return nil, false
+	}
+
+	return c, true
+}
+
+// addComment adds a new comment.
+func (s *state) addComment(u *user, it *item, parent *comment, b *commentBody, now time.Time) *comment {
+	c := &comment{
+		id:        s.nextID(),
+		text:      b.Comment,
+		spoiler:   b.Spoiler,
+		createdAt: now,
+		updatedAt: now,
+		user:      u,
+		item:      it,
+		likes:     make(map[string]time.Time),
+	}
+
+	if parent != nil {
+		c.parent = parent.id
+		parent.replies++
+	}
+
+	s.comments = append(s.comments, c)
+	u.touch(now, it.group(), "commented_at")
+	return c
+}
+
+// postComment handles POST /comments.
+func (s *Server) postComment(w http.ResponseWriter, r *request) {
+	b, ok := decodeCommentBody(w, r)
+	if !ok {
+		return
+	}
+
+	it, ok := s.findItem(&b.mediaBody)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "item not found")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.addComment(r.user, it, nil, b, r.now).json())
+}
+
+// getComment handles GET /comments/:id.
+func (s *Server) getComment(w http.ResponseWriter, r *request) {
+	if c, ok := s.findComment(w, r); ok {
+		writeJSON(w, http.StatusOK, c.json())
+	}
+}
+
+// updateComment handles PUT /comments/:id.
+func (s *Server) updateComment(w http.ResponseWriter, r *request) {
+	c, ok := s.ownComment(w, r)
+	if !ok {
+		return
+	}
+
+	b, ok := decodeCommentBody(w, r)
+	if !ok {
+		return
+	}
+
+	c.text, c.spoiler, c.updatedAt = b.Comment, b.Spoiler, r.now
+	writeJSON(w, http.StatusOK, c.json())
+}
+
+// removeComment handles DELETE /comments/:id. A comment cannot be removed once it has replies.
+func (s *Server) removeComment(w http.ResponseWriter, r *request) {
+	c, ok := s.ownComment(w, r)
+	if !ok {
+		return
+	}
+
+	if c.replies > 0 {
+		writeError(w, http.StatusConflict, "comment_cannot_be_removed", "a comment with replies cannot be removed")
+		return
+	}
+
+	comments := s.comments[:0]
+	for _, o := range s.comments {
+		if o.id == c.parent {
+			o.replies--
+		}
+
+		if o != c {
+			comments = append(comments, o)
+		}
+	}
+
+	s.comments = comments
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listReplies handles GET /comments/:id/replies.
+func (s *Server) listReplies(w http.ResponseWriter, r *request) {
+	c, ok := s.findComment(w, r)
+	if !ok {
+		return
+	}
+
+	items := make([]interface{}, 0, c.replies)
+	for _, o := range s.comments {
+		if o.parent == c.id {
+			items = append(items, o.json())
+		}
+	}
+
+	writePage(w, r, items)
+}
+
+// addReply handles POST /comments/:id/replies.
+func (s *Server) addReply(w http.ResponseWriter, r *request) {
+	c, ok := s.findComment(w, r)
+	if !ok {
+		return
+	}
+
+	b, ok := decodeCommentBody(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.addComment(r.user, c.item, c, b, r.now).json())
+}
+
+// commentItem handles GET /comments/:id/item.
+func (s *Server) commentItem(w http.ResponseWriter, r *request) {
+	if c, ok := s.findComment(w, r); ok {
+		writeJSON(w, http.StatusOK, c.item.json())
+	}
+}
+
+// listLikes handles GET /comments/:id/likes.
+func (s *Server) listLikes(w http.ResponseWriter, r *request) {
+	c, ok := s.findComment(w, r)
+	if !ok {
+		return
+	}
+
+	users := make([]string, 0, len(c.likes))
+	for u := range c.likes {
+		users = append(users, u)
+	}
+
+	sort.Slice(users, func(i, j int) bool { return c.likes[users[i]].After(c.likes[users[j]]) })
+
+	items := make([]interface{}, 0, len(users))
+	for _, u := range users {
+		items = append(items, map[string]interface{}{
+			"liked_at": formatTime(c.likes[u]),
+			"user":     s.users[u].json(),
+		})
+	}
+
+	writePage(w, r, items)
+}
+
+// likeComment handles POST /comments/:id/like.
+func (s *Server) likeComment(w http.ResponseWriter, r *request) {
+	c, ok := s.findComment(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := c.likes[r.user.username]; !ok {
+		c.likes[r.user.username] = r.now
+		r.user.touch(r.now, "comments", "liked_at")
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// unlikeComment handles DELETE /comments/:id/like.
+func (s *Server) unlikeComment(w http.ResponseWriter, r *request) {
+	c, ok := s.findComment(w, r)
+	if !ok {
+		return
+	}
+
+	delete(c.likes, r.user.username)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listComments handles GET /comments/:list/:comment_type/:type for the trending,
+// recent and updated comment lists.
+func (s *Server) listComments(w http.ResponseWriter, r *request) {
+	var less func(a, b *comment) bool
+	switch r.vars["list"] {
+	case "trending":
+		less = func(a, b *comment) bool { return a.replies+len(a.likes) > b.replies+len(b.likes) }
+	case "recent":
+		less = func(a, b *comment) bool { return a.createdAt.After(b.createdAt) }
+	case "updates":
+		less = func(a, b *comment) bool { return a.updatedAt.After(b.updatedAt) }
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "unknown comment list")
+		return
+	}
+
+	ct, typ := r.vars["comment_type"], trakt.Type(strings.TrimSuffix(r.vars["type"], "s"))
+	comments := make([]*comment, 0, len(s.comments))
+	for _, c := range s.comments {
+		review := len(strings.Fields(c.text)) >= reviewWords
+		switch {
+		case ct == string(trakt.CommentTypeReview) && !review:
+		case ct == string(trakt.CommentTypeShout) && review:
+		case typ != trakt.Type(trakt.All) && c.item.typ != typ:
+		default:
+			comments = append(comments, c)
+		}
+	}
+
+	sort.SliceStable(comments, func(i, j int) bool { return less(comments[i], comments[j]) })
+
+	items := make([]interface{}, 0, len(comments))
+	for _, c := range comments {
+		items = append(items, c.item.merge(map[string]interface{}{"comment": c.json()}))
+	}
+
+	writePage(w, r, items)
+}
+
+// itemComments handles GET /movies/:id/comments/:sort and GET /shows/:id/comments/:sort
+// which list the top level comments on a movie or show.
+func (s *Server) itemComments(typ trakt.Type) handler {
+	return func(w http.ResponseWriter, r *request) {
+		it, ok := s.findByID(typ, r.vars["id"])
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found", string(typ)+" not found")
+			return
+		}
+
+		comments := make([]*comment, 0)
+		for _, c := range s.comments {
+			if c.parent == 0 && c.item.key() == it.key() {
+				comments = append(comments, c)
+			}
+		}
+
+		switch r.vars["sort"] {
+		case "oldest":
+			sort.SliceStable(comments, func(i, j int) bool { return comments[i].createdAt.Before(comments[j].createdAt) })
+		case "likes":
+			sort.SliceStable(comments, func(i, j int) bool { return len(comments[i].likes) > len(comments[j].likes) })
+		case "replies":
+			sort.SliceStable(comments, func(i, j int) bool { return comments[i].replies > comments[j].replies })
+		default:
+			sort.SliceStable(comments, func(i, j int) bool { return comments[i].createdAt.After(comments[j].createdAt) })
+		}
+
+		items := make([]interface{}, 0, len(comments))
+		for _, c := range comments {
+			items = append(items, c.json())
+		}
+
+		writePage(w, r, items)
+	}
+}
@@ -0,0 +1,27 @@
+// Package trakttest provides an in-memory fake of the trakt API which can be used to test code
+// built on this library without access to the network.
+//
+// The fake server implements the catalogue, sync, checkin, scrobble, comment, calendar, search and
+// OAuth device / token endpoints against an in-memory state store. List endpoints emit the same
+// X-Pagination-* headers as the real API and failures use the status codes trakt documents, for
+// example a 409 when a checkin is already in progress or a 400 while a device code is pending.
+//
+// A test seeds the catalogue, authorizes a user and points the library at the server:
+//
+//  srv := trakttest.NewServer()
+//  defer srv.Close()
+//
+//  srv.AddMovie(&trakttest.Movie{Title: "Tron: Legacy", Year: 2010})
+//  token := srv.Authorize("sean")
+//
+//  trakt.Key = "client-id"
+//  trakt.WithConfig(srv.BackendConfig())
+//  _, err := checkin.Start(&trakt.StartCheckinParams{
+//  	Params:  trakt.Params{OAuth: token},
+//  	Type:    trakt.TypeMovie,
+//  	Element: &trakt.GenericElementParams{IDs: trakt.MediaIDs{Slug: "tron-legacy-2010"}},
+//  })
+//
+// Every request must supply a trakt-api-key header, and endpoints which require OAuth must supply
+// a bearer token which has either been issued using Authorize or through one of the OAuth flows.
+package trakttest
@@ -0,0 +1,124 @@
+package trakttest_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+	"github.com/jacklaaa89/trakt/authorization"
+	"github.com/jacklaaa89/trakt/checkin"
+	"github.com/jacklaaa89/trakt/show"
+	"github.com/jacklaaa89/trakt/sync"
+	"github.com/jacklaaa89/trakt/trakttest"
+)
+
+func ExampleServer() {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	srv.AddMovie(&trakttest.Movie{Title: "Tron: Legacy", Year: 2010})
+	token := srv.Authorize("sean")
+
+	c := checkin.NewClient(trakt.New("client-id", srv.BackendConfig()))
+	params := &trakt.StartCheckinParams{
+		Params:  trakt.Params{OAuth: token},
+		Type:    trakt.TypeMovie,
+		Element: &trakt.GenericElementParams{IDs: trakt.MediaIDs{Slug: "tron-legacy-2010"}},
+	}
+
+	ci, err := c.Start(params)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(ci.Movie.Title)
+
+	_, err = c.Start(params)
+	fmt.Println(errors.Is(err, trakt.ErrCheckinInProgress))
+	// Output:
+	// Tron: Legacy
+	// true
+}
+
+func ExampleServer_ApproveDevice() {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	tc := trakt.New("client-id", srv.BackendConfig())
+	auth := authorization.NewClient(tc)
+
+	code, err := auth.NewCode(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	ch := auth.PollAsync(&trakt.PollCodeParams{
+		Code:         code.Code,
+		ClientSecret: "secret",
+		Interval:     10 * time.Millisecond,
+		ExpiresIn:    code.ExpiresIn,
+	})
+
+	if err := srv.ApproveDevice(code.UserCode, "sean"); err != nil {
+		panic(err)
+	}
+
+	res := <-ch
+	if res.Err != nil {
+		panic(res.Err)
+	}
+
+	srv.AddShow(&trakttest.Show{
+		Title: "Breaking Bad",
+		Year:  2008,
+		Seasons: []*trakttest.Season{
+			{Number: 1, Episodes: []*trakttest.Episode{{Number: 1}, {Number: 2}}},
+		},
+	})
+
+	added, err := sync.NewClient(tc).AddToHistory(&trakt.AddToHistoryParams{
+		Params: trakt.Params{OAuth: res.Token.AccessToken},
+		Shows:  []*trakt.ShowHistoryParams{{IDs: trakt.MediaIDs{Slug: "breaking-bad-2008"}}},
+	})
+
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(added.Added.Episodes)
+	// Output:
+	// 2
+}
+
+func ExampleServer_typedIterator() {
+	srv := trakttest.NewServer()
+	defer srv.Close()
+
+	srv.AddShow(&trakttest.Show{
+		Title: "Breaking Bad",
+		Year:  2008,
+		Seasons: []*trakttest.Season{
+			{Number: 1, Episodes: []*trakttest.Episode{{Number: 1}, {Number: 2}}},
+			{Number: 2, Episodes: []*trakttest.Episode{{Number: 1}}},
+		},
+	})
+
+	c := show.NewClient(trakt.New("client-id", srv.BackendConfig()))
+	it := c.Seasons(trakt.Slug("breaking-bad-2008"), &trakt.ExtendedListParams{Extended: trakt.ExtendedTypeEpisodes}).Iter()
+	for it.Next() {
+		s, err := it.Current()
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(s.Number, len(s.Episodes))
+	}
+
+	if err := it.Err(); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1 2
+	// 2 1
+}
@@ -0,0 +1,264 @@
+package trakttest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// device code states.
+const (
+	devicePending = iota
+	deviceApproved
+	deviceDenied
+	deviceUsed
+)
+
+// ErrUnknownUserCode is returned when approving or denying a user code which has not been issued.
+var ErrUnknownUserCode = errors.New("trakttest: unknown user code")
+
+// token an issued access token.
+type token struct {
+	user      *user
+	access    string
+	refresh   string
+	createdAt time.Time
+}
+
+// json generates the representation of the token.
+func (t *token) json() map[string]interface{} {
+	return map[string]interface{}{
+		"access_token":  t.access,
+		"token_type":    "bearer",
+		"expires_in":    int64(defaultTokenExpiresIn / time.Second),
+		"refresh_token": t.refresh,
+		"scope":         "public",
+		"created_at":    t.createdAt.Unix(),
+	}
+}
+
+// deviceCode an issued device code.
+type deviceCode struct {
+	code      string
+	userCode  string
+	expiresAt time.Time
+	status    int
+	user      *user
+}
+
+// AuthorizationCode issues a new authorization code for the user with the supplied username which can
+// be exchanged for an access token, the user is created if they do not already exist. This simulates
+// the user completing the OAuth authorization flow.
+func (s *Server) AuthorizationCode(username string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	code := randomString(16)
+	s.codes[code] = s.getUser(username).username
+	return code
+}
+
+// ApproveDevice approves the device code with the supplied user code on behalf of the user with
+// the supplied username. The next poll for the device code will receive an access token.
+func (s *Server) ApproveDevice(userCode, username string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.findUserCode(userCode)
+	if !ok {
+		return ErrUnknownUserCode
+	}
+
+	d.status, d.user = deviceApproved, s.getUser(username)
+	return nil
+}
+
+// DenyDevice denies the device code with the supplied user code.
+func (s *Server) DenyDevice(userCode string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.findUserCode(userCode)
+	if !ok {
+		return ErrUnknownUserCode
+	}
+
+	d.status = deviceDenied
+	return nil
+}
+
+// findUserCode finds the device code which was issued with the supplied user code.
+func (s *state) findUserCode(userCode string) (*deviceCode, bool) {
+	for _, d := range s.devices {
+		if d.userCode == userCode {
+			return d, true
+		}
+	}
+
+	return nil, false
+}
+
+// issueToken issues a new access token for the user.
+func (s *state) issueToken(u *user, now time.Time) *token {
+	t := &token{user: u, access: randomString(32), refresh: randomString(32), createdAt: now}
+	s.tokens[t.access] = t
+	s.refresh[t.refresh] = t
+	return t
+}
+
+// userForRequest retrieves the user authenticated by the bearer token on the request.
+func (s *state) userForRequest(r *http.Request) (*user, bool) {
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, "Bearer ") {
+		return nil, false
+	}
+
+	t, ok := s.tokens[strings.TrimPrefix(h, "Bearer ")]
+	if !ok {
+		return nil, false
+	}
+
+	return t.user, true
+}
+
+// newDeviceCode handles POST /oauth/device/code.
+func (s *Server) newDeviceCode(w http.ResponseWriter, r *request) {
+	var b struct {
+		ClientID string `json:"client_id"`
+	}
+
+	if err := r.decode(&b); err != nil || b.ClientID == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "client_id is required")
+		return
+	}
+
+	d := &deviceCode{
+		code:      randomString(20),
+		userCode:  strings.ToUpper(randomString(4)),
+		expiresAt: r.now.Add(s.opts.DeviceCodeExpiresIn),
+	}
+
+	s.devices[d.code] = d
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"device_code":      d.code,
+		"user_code":        d.userCode,
+		"verification_url": s.srv.URL + "/activate",
+		"expires_in":       int64(s.opts.DeviceCodeExpiresIn / time.Second),
+		"interval":         int64((s.opts.DeviceCodeInterval + time.Second - 1) / time.Second),
+	})
+}
+
+// pollDeviceCode handles POST /oauth/device/token.
+func (s *Server) pollDeviceCode(w http.ResponseWriter, r *request) {
+	var b struct {
+		Code         string `json:"code"`
+		ClientSecret string `json:"client_secret"`
+	}
+
+	if err := r.decode(&b); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	d, ok := s.devices[b.Code]
+	switch {
+	case !ok:
+		writeError(w, http.StatusNotFound, "invalid_device_code", "invalid device code")
+	case d.status == deviceUsed:
+		writeError(w, http.StatusConflict, "already_used", "the device code has already been approved")
+	case !r.now.Before(d.expiresAt):
+		writeError(w, http.StatusGone, "expired", "the device code has expired, restart the process")
+	case d.status == deviceDenied:
+		writeError(w, http.StatusTeapot, "denied", "the user explicitly denied this code")
+	case d.status == devicePending:
+		writeError(w, http.StatusBadRequest, "authorization_pending", "waiting for the user to authorize the app")
+	default:
+		d.status = deviceUsed
+		writeJSON(w, http.StatusOK, s.issueToken(d.user, r.now).json())
+	}
+}
+
+// exchangeToken handles POST /oauth/token for both the authorization_code and refresh_token grants.
+func (s *Server) exchangeToken(w http.ResponseWriter, r *request) {
+	var b struct {
+		Code         string `json:"code"`
+		RefreshToken string `json:"refresh_token"`
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+		GrantType    string `json:"grant_type"`
+	}
+
+	if err := r.decode(&b); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	if b.ClientID == "" || b.ClientSecret == "" {
+		writeError(w, http.StatusUnauthorized, "invalid_client", "client authentication failed")
+		return
+	}
+
+	switch b.GrantType {
+	case "authorization_code":
+		username, ok := s.codes[b.Code]
+		if !ok {
+			writeInvalidGrant(w)
+			return
+		}
+
+		delete(s.codes, b.Code)
+		writeJSON(w, http.StatusOK, s.issueToken(s.users[username], r.now).json())
+	case "refresh_token":
+		t, ok := s.refresh[b.RefreshToken]
+		if !ok {
+			writeInvalidGrant(w)
+			return
+		}
+
+		s.revoke(t)
+		writeJSON(w, http.StatusOK, s.issueToken(t.user, r.now).json())
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type", "the grant type is not supported")
+	}
+}
+
+// revokeToken handles POST /oauth/revoke.
+func (s *Server) revokeToken(w http.ResponseWriter, r *request) {
+	var b struct {
+		Token string `json:"token"`
+	}
+
+	if err := r.decode(&b); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	if t, ok := s.tokens[b.Token]; ok {
+		s.revoke(t)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// revoke revokes both the access and refresh token.
+func (s *state) revoke(t *token) {
+	delete(s.tokens, t.access)
+	delete(s.refresh, t.refresh)
+}
+
+// writeInvalidGrant writes the error returned when an authorization code or refresh token is invalid.
+func writeInvalidGrant(w http.ResponseWriter) {
+	writeError(w, http.StatusUnauthorized, "invalid_grant", "the provided authorization grant is invalid, "+
+		"expired, revoked, does not match the redirection URI used in the authorization request, "+
+		"or was issued to another client")
+}
+
+// randomString generates a random hex string from n random bytes.
+func randomString(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
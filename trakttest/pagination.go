@@ -0,0 +1,62 @@
+package trakttest
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// default pagination values, these match the defaults of the API.
+const (
+	defaultPage  = 1
+	defaultLimit = 10
+)
+
+// writePage writes a single page of items setting the X-Pagination-* headers.
+func writePage(w http.ResponseWriter, r *request, items []interface{}) {
+	page := queryInt(r, "page", defaultPage)
+	limit := queryInt(r, "limit", defaultLimit)
+
+	total := int64(len(items))
+	pages := (total + limit - 1) / limit
+
+	start, end := (page-1)*limit, page*limit
+	if start > total {
+		start = total
+	}
+
+	if end > total {
+		end = total
+	}
+
+	h := w.Header()
+	h.Set("X-Pagination-Page", strconv.FormatInt(page, 10))
+	h.Set("X-Pagination-Limit", strconv.FormatInt(limit, 10))
+	h.Set("X-Pagination-Page-Count", strconv.FormatInt(pages, 10))
+	h.Set("X-Pagination-Item-Count", strconv.FormatInt(total, 10))
+
+	writeJSON(w, http.StatusOK, items[start:end])
+}
+
+// writeOptionalPage writes a single page of items if pagination was requested,
+// otherwise all of the items are written. This is how endpoints where pagination
+// is optional behave.
+func writeOptionalPage(w http.ResponseWriter, r *request, items []interface{}) {
+	q := r.URL.Query()
+	if q.Get("page") != "" || q.Get("limit") != "" {
+		writePage(w, r, items)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, items)
+}
+
+// queryInt parses a positive integer from the query string, returning def if
+// the value is not supplied or is invalid.
+func queryInt(r *request, key string, def int64) int64 {
+	v, err := strconv.ParseInt(r.URL.Query().Get(key), 10, 64)
+	if err != nil || v < 1 {
+		return def
+	}
+
+	return v
+}
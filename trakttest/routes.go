@@ -0,0 +1,79 @@
+package trakttest
+
+import "net/http"
+
+// registerRoutes registers every route the server handles.
+func (s *Server) registerRoutes() {
+	// oauth.
+	s.handle(http.MethodPost, "/oauth/device/code", s.newDeviceCode)
+	s.handle(http.MethodPost, "/oauth/device/token", s.pollDeviceCode)
+	s.handle(http.MethodPost, "/oauth/token", s.exchangeToken)
+	s.handle(http.MethodPost, "/oauth/revoke", s.revokeToken)
+
+	// catalogue.
+	s.handle(http.MethodGet, "/movies/:id", s.getMovie)
+	s.handle(http.MethodGet, "/movies/:id/comments", s.itemComments("movie"))
+	s.handle(http.MethodGet, "/movies/:id/comments/:sort", s.itemComments("movie"))
+	s.handle(http.MethodGet, "/shows/:id", s.getShow)
+	s.handle(http.MethodGet, "/shows/:id/comments", s.itemComments("show"))
+	s.handle(http.MethodGet, "/shows/:id/comments/:sort", s.itemComments("show"))
+	s.handle(http.MethodGet, "/shows/:id/seasons", s.listSeasons)
+	s.handle(http.MethodGet, "/shows/:id/seasons/:season", s.getSeason)
+	s.handle(http.MethodGet, "/shows/:id/seasons/:season/episodes/:episode", s.getEpisode)
+
+	// search.
+	s.handle(http.MethodGet, "/search/:type", s.textSearch)
+	s.handle(http.MethodGet, "/search/:id_type/:id", s.idLookup)
+
+	// calendars, the my calendars are scoped to the authenticated user.
+	for scope, register := range map[string]func(string, string, handler){"my": s.handleOAuth, "all": s.handle} {
+		register(http.MethodGet, "/calendars/"+scope+"/shows/:start/:days", s.calendarShows(allEpisodes))
+		register(http.MethodGet, "/calendars/"+scope+"/shows/new/:start/:days", s.calendarShows(newShows))
+		register(http.MethodGet, "/calendars/"+scope+"/shows/premieres/:start/:days", s.calendarShows(seasonPremieres))
+		register(http.MethodGet, "/calendars/"+scope+"/movies/:start/:days", s.calendarMovies(released))
+		register(http.MethodGet, "/calendars/"+scope+"/dvd/:start/:days", s.calendarMovies(dvdReleased))
+	}
+
+	// comments.
+	s.handleOAuth(http.MethodPost, "/comments", s.postComment)
+	s.handle(http.MethodGet, "/comments/:id", s.getComment)
+	s.handleOAuth(http.MethodPut, "/comments/:id", s.updateComment)
+	s.handleOAuth(http.MethodDelete, "/comments/:id", s.removeComment)
+	s.handle(http.MethodGet, "/comments/:id/replies", s.listReplies)
+	s.handleOAuth(http.MethodPost, "/comments/:id/replies", s.addReply)
+	s.handle(http.MethodGet, "/comments/:id/item", s.commentItem)
+	s.handle(http.MethodGet, "/comments/:id/likes", s.listLikes)
+	s.handleOAuth(http.MethodPost, "/comments/:id/like", s.likeComment)
+	s.handleOAuth(http.MethodDelete, "/comments/:id/like", s.unlikeComment)
+	s.handle(http.MethodGet, "/comments/:list/:comment_type/:type", s.listComments)
+
+	// checkin & scrobble.
+	s.handleOAuth(http.MethodPost, "/checkin", s.startCheckin)
+	s.handleOAuth(http.MethodDelete, "/checkin", s.stopCheckin)
+	s.handleOAuth(http.MethodPost, "/scrobble/:event", s.scrobble)
+
+	// sync.
+	s.handleOAuth(http.MethodGet, "/sync/last_activities", s.lastActivities)
+	s.handleOAuth(http.MethodGet, "/sync/playback", s.listPlayback)
+	s.handleOAuth(http.MethodGet, "/sync/playback/:type", s.listPlayback)
+	s.handleOAuth(http.MethodDelete, "/sync/playback/:id", s.removePlayback)
+	s.handleOAuth(http.MethodGet, "/sync/collection/:type", s.listCollection)
+	s.handleOAuth(http.MethodPost, "/sync/collection", s.addToCollection)
+	s.handleOAuth(http.MethodPost, "/sync/collection/remove", s.removeFromCollection)
+	s.handleOAuth(http.MethodGet, "/sync/watched/:type", s.listWatched)
+	s.handleOAuth(http.MethodGet, "/sync/history", s.listHistory)
+	s.handleOAuth(http.MethodGet, "/sync/history/:type", s.listHistory)
+	s.handleOAuth(http.MethodGet, "/sync/history/:type/:id", s.listHistory)
+	s.handleOAuth(http.MethodPost, "/sync/history", s.addToHistory)
+	s.handleOAuth(http.MethodPost, "/sync/history/remove", s.removeFromHistory)
+	s.handleOAuth(http.MethodGet, "/sync/ratings", s.listRatings)
+	s.handleOAuth(http.MethodGet, "/sync/ratings/:type", s.listRatings)
+	s.handleOAuth(http.MethodGet, "/sync/ratings/:type/:rating", s.listRatings)
+	s.handleOAuth(http.MethodPost, "/sync/ratings", s.addRatings)
+	s.handleOAuth(http.MethodPost, "/sync/ratings/remove", s.removeRatings)
+	s.handleOAuth(http.MethodGet, "/sync/watchlist", s.listWatchList)
+	s.handleOAuth(http.MethodGet, "/sync/watchlist/:type", s.listWatchList)
+	s.handleOAuth(http.MethodGet, "/sync/watchlist/:type/:sort", s.listWatchList)
+	s.handleOAuth(http.MethodPost, "/sync/watchlist", s.addToWatchList)
+	s.handleOAuth(http.MethodPost, "/sync/watchlist/remove", s.removeFromWatchList)
+}
@@ -0,0 +1,162 @@
+package trakttest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// scores given to search results based on how closely the title matched the query.
+const (
+	scoreExact     = 1000
+	scorePrefix    = 500
+	scoreSubstring = 100
+)
+
+// result a single search result.
+type result struct {
+	item  *item
+	score int
+}
+
+// json generates the representation of the result.
+func (r *result) json() map[string]interface{} {
+	return r.item.merge(map[string]interface{}{"score": r.score})
+}
+
+// catalogue returns every movie, show and episode in the catalogue.
+func (s *state) catalogue() []*item {
+	var items []*item
+	for _, m := range s.movies {
+		items = append(items, &item{typ: trakt.TypeMovie, movie: m})
+	}
+
+	for _, sh := range s.shows {
+		items = append(items, &item{typ: trakt.TypeShow, show: sh})
+		for _, sn := range sh.Seasons {
+			for _, e := range sn.Episodes {
+				items = append(items, &item{typ: trakt.TypeEpisode, show: sh, season: sn, episode: e})
+			}
+		}
+	}
+
+	return items
+}
+
+// textSearch handles GET /search/:type, the type can be a comma separated list of types.
+func (s *Server) textSearch(w http.ResponseWriter, r *request) {
+	q := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("query")))
+	if q == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "a query is required")
+		return
+	}
+
+	types := searchTypes(r.vars["type"])
+	years := make(map[int64]bool)
+	for _, y := range strings.Split(r.URL.Query().Get("years"), ",") {
+		if n, err := strconv.ParseInt(y, 10, 64); err == nil {
+			years[n] = true
+		}
+	}
+
+	var results []*result
+	for _, it := range s.catalogue() {
+		if !types[it.typ] || (len(years) > 0 && !years[year(it)]) {
+			continue
+		}
+
+		t := strings.ToLower(searchTitle(it))
+		switch {
+		case t == q:
+			results = append(results, &result{it, scoreExact})
+		case strings.HasPrefix(t, q):
+			results = append(results, &result{it, scorePrefix})
+		case strings.Contains(t, q):
+			results = append(results, &result{it, scoreSubstring})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].score > results[j].score })
+	writeResults(w, r, results)
+}
+
+// idLookup handles GET /search/:id_type/:id, results can be filtered by
+// supplying a comma separated list of types in the query string.
+func (s *Server) idLookup(w http.ResponseWriter, r *request) {
+	id := r.vars["id"]
+	e := &element{}
+	switch r.vars["id_type"] {
+	case "trakt":
+		n, _ := strconv.ParseInt(id, 10, 64)
+		e.IDs.Trakt = trakt.ID(n)
+	case "imdb":
+		e.IDs.IMDB = trakt.IMDB(id)
+	case "tmdb":
+		n, _ := strconv.ParseInt(id, 10, 64)
+		e.IDs.TMDB = trakt.TMDB(n)
+	case "tvdb":
+		n, _ := strconv.ParseInt(id, 10, 64)
+		e.IDs.TVDB = trakt.TVDB(n)
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "unknown id type")
+		return
+	}
+
+	types := searchTypes(r.URL.Query().Get("type"))
+
+	var results []*result
+	for _, it := range s.catalogue() {
+		if types[it.typ] && matchesIDs(e.IDs, it.ids()) {
+			results = append(results, &result{it, scoreExact})
+		}
+	}
+
+	writeResults(w, r, results)
+}
+
+// writeResults writes a page of search results.
+func writeResults(w http.ResponseWriter, r *request, results []*result) {
+	items := make([]interface{}, 0, len(results))
+	for _, res := range results {
+		items = append(items, res.json())
+	}
+
+	writePage(w, r, items)
+}
+
+// searchTypes parses a comma separated list of types, an empty list matches every type.
+func searchTypes(v string) map[trakt.Type]bool {
+	types := make(map[trakt.Type]bool)
+	for _, t := range strings.Split(v, ",") {
+		if t != "" {
+			types[trakt.Type(t)] = true
+		}
+	}
+
+	if len(types) == 0 {
+		types[trakt.TypeMovie], types[trakt.TypeShow], types[trakt.TypeEpisode] = true, true, true
+	}
+
+	return types
+}
+
+// searchTitle the title the item is matched on.
+func searchTitle(i *item) string {
+	if i.typ == trakt.TypeEpisode {
+		return i.episode.Title
+	}
+
+	return title(i)
+}
+
+// year the year of the movie or show the item belongs to.
+func year(i *item) int64 {
+	if i.typ == trakt.TypeMovie {
+		return i.movie.Year
+	}
+
+	return i.show.Year
+}
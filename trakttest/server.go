@@ -0,0 +1,270 @@
+package trakttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// default values applied to Options.
+const (
+	defaultDeviceCodeInterval  = time.Second
+	defaultDeviceCodeExpiresIn = 10 * time.Minute
+	defaultCheckinDuration     = 2 * time.Hour
+	defaultTokenExpiresIn      = 90 * 24 * time.Hour
+)
+
+// timeFormat the format trakt uses for timestamps.
+const timeFormat = "2006-01-02T15:04:05.000Z"
+
+// Options is used to configure a new Server.
+type Options struct {
+	// DeviceCodeInterval the interval a device code should be polled on.
+	// trakt only supports whole seconds, so this is rounded up to the nearest second.
+	//
+	// Defaults to one second.
+	DeviceCodeInterval time.Duration
+
+	// DeviceCodeExpiresIn the duration a device code is valid for.
+	//
+	// Defaults to 10 minutes.
+	DeviceCodeExpiresIn time.Duration
+
+	// CheckinDuration the duration a checkin is active for, another checkin
+	// cannot be started until this has elapsed or the checkin is stopped.
+	//
+	// Defaults to 2 hours.
+	CheckinDuration time.Duration
+
+	// Now is used to retrieve the current time.
+	//
+	// Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Server is a fake trakt API server backed by an in-memory state store.
+type Server struct {
+	// mu protects the state store.
+	mu sync.Mutex
+
+	// srv the underlying test server.
+	srv *httptest.Server
+	// opts the options the server was configured with.
+	opts *Options
+	// routes the set of routes the server handles.
+	routes []*route
+	// state the in-memory state store.
+	*state
+}
+
+// NewServer starts a new fake trakt server using the default options.
+// The server should be closed once it is no longer required.
+func NewServer() *Server { return NewServerWithOptions(nil) }
+
+// NewServerWithOptions starts a new fake trakt server using the supplied options.
+// The server should be closed once it is no longer required.
+func NewServerWithOptions(opts *Options) *Server {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	o := *opts
+	if o.DeviceCodeInterval <= 0 {
+		o.DeviceCodeInterval = defaultDeviceCodeInterval
+	}
+
+	if o.DeviceCodeExpiresIn <= 0 {
+		o.DeviceCodeExpiresIn = defaultDeviceCodeExpiresIn
+	}
+
+	if o.CheckinDuration <= 0 {
+		o.CheckinDuration = defaultCheckinDuration
+	}
+
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+
+	s := &Server{opts: &o, state: newState()}
+	s.registerRoutes()
+	s.srv = httptest.NewServer(s)
+	return s
+}
+
+// URL returns the base URL of the server.
+func (s *Server) URL() string { return s.srv.URL }
+
+// BackendConfig returns a backend configuration which points the library at the server.
+// Rate limiting is disabled as the server does not enforce any limits.
+func (s *Server) BackendConfig() *trakt.BackendConfig {
+	return &trakt.BackendConfig{
+		URL:            s.srv.URL,
+		HTTPClient:     s.srv.Client(),
+		GetRateLimit:   trakt.NoRateLimit,
+		WriteRateLimit: trakt.NoRateLimit,
+	}
+}
+
+// Close shuts down the server, blocking until all outstanding requests have completed.
+func (s *Server) Close() { s.srv.Close() }
+
+// ServeHTTP implements http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// every response carries a request ID, like the real API.
+	w.Header().Set("X-Request-ID", randomString(16))
+
+	if r.Header.Get("trakt-api-key") == "" {
+		writeError(w, http.StatusForbidden, "invalid_api_key", "a valid trakt-api-key header is required")
+		return
+	}
+
+	rt, vars, methodMatched := s.match(r.Method, r.URL.Path)
+	switch {
+	case rt == nil && methodMatched:
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed")
+		return
+	case rt == nil:
+		writeError(w, http.StatusNotFound, "not_found", "resource not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req := &request{Request: r, vars: vars, now: s.opts.Now().UTC()}
+	if rt.oauth {
+		u, ok := s.userForRequest(r)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "invalid_token", "a valid OAuth token is required")
+			return
+		}
+
+		req.user = u
+	}
+
+	rt.h(w, req)
+}
+
+// request a request which has been matched to a route.
+type request struct {
+	*http.Request
+
+	// vars the variables captured from the path.
+	vars map[string]string
+	// user the authenticated user, only set for routes which require OAuth.
+	user *user
+	// now the time the request was received.
+	now time.Time
+}
+
+// decode decodes the JSON request body into v.
+func (r *request) decode(v interface{}) error { return json.NewDecoder(r.Body).Decode(v) }
+
+// handler handles a request which has been matched to a route.
+type handler func(w http.ResponseWriter, r *request)
+
+// route a single route the server handles.
+type route struct {
+	// method the HTTP method the route handles.
+	method string
+	// segments the path segments, a segment starting with ':' is a variable.
+	segments []string
+	// oauth whether a valid OAuth token is required.
+	oauth bool
+	// h the handler for the route.
+	h handler
+}
+
+// handle registers a route which does not require OAuth.
+func (s *Server) handle(method, pattern string, h handler) { s.addRoute(method, pattern, false, h) }
+
+// handleOAuth registers a route which requires OAuth.
+func (s *Server) handleOAuth(method, pattern string, h handler) { s.addRoute(method, pattern, true, h) }
+
+// addRoute registers a new route.
+func (s *Server) addRoute(method, pattern string, oauth bool, h handler) {
+	s.routes = append(s.routes, &route{method: method, segments: split(pattern), oauth: oauth, h: h})
+}
+
+// match finds the first route which matches the method and path. If no route matches
+// we also return whether a route matched the path using a different method.
+func (s *Server) match(method, path string) (*route, map[string]string, bool) {
+	segments, pathMatched := split(path), false
+	for _, rt := range s.routes {
+		vars, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+
+		if rt.method != method {
+			pathMatched = true
+			continue
+		}
+
+		return rt, vars, false
+	}
+
+	return nil, nil, pathMatched
+}
+
+// match determines if the route matches the supplied path segments,
+// returning the variables captured from the path.
+func (rt *route) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+
+	vars := make(map[string]string)
+	for i, s := range rt.segments {
+		switch {
+		case strings.HasPrefix(s, ":"):
+			vars[s[1:]] = segments[i]
+		case s != segments[i]:
+			return nil, false
+		}
+	}
+
+	return vars, true
+}
+
+// split splits a path into its segments.
+func split(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, "/")
+}
+
+// errorResponse the body written for an error response.
+type errorResponse struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, &errorResponse{Error: code, Description: description})
+}
+
+// writeJSON writes v as a JSON response. Like the real API, an empty list
+// is written as an empty array rather than null.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		v = []interface{}{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// formatTime formats a time as trakt would.
+func formatTime(t time.Time) string { return t.UTC().Format(timeFormat) }
@@ -0,0 +1,244 @@
+package trakttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// clock a controllable clock used as the current time of the server.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// do performs a raw request against the server, decoding the JSON response into rcv if supplied.
+func do(t *testing.T, s *Server, method, path, token string, body, rcv interface{}) *http.Response {
+	t.Helper()
+
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+
+	req, _ := http.NewRequest(method, s.URL()+path, bytes.NewReader(b))
+	req.Header.Set("trakt-api-key", "client-id")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := s.srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	defer res.Body.Close()
+	raw, _ := ioutil.ReadAll(res.Body)
+	res.Body = ioutil.NopCloser(bytes.NewReader(raw))
+	if rcv != nil {
+		_ = json.Unmarshal(raw, rcv)
+	}
+
+	return res
+}
+
+func TestServer_PollDeviceCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		setup  func(s *Server, c *clock, userCode string)
+		status int
+	}{
+		{name: "unknown", code: "unknown", status: http.StatusNotFound},
+		{name: "pending", status: http.StatusBadRequest},
+		{
+			name:   "approved",
+			setup:  func(s *Server, _ *clock, userCode string) { _ = s.ApproveDevice(userCode, "sean") },
+			status: http.StatusOK,
+		},
+		{
+			name:   "denied",
+			setup:  func(s *Server, _ *clock, userCode string) { _ = s.DenyDevice(userCode) },
+			status: http.StatusTeapot,
+		},
+		{
+			name:   "expired",
+			setup:  func(_ *Server, c *clock, _ string) { c.advance(defaultDeviceCodeExpiresIn) },
+			status: http.StatusGone,
+		},
+		{
+			name: "already used",
+			setup: func(s *Server, _ *clock, userCode string) {
+				_ = s.ApproveDevice(userCode, "sean")
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				d, _ := s.findUserCode(userCode)
+				d.status = deviceUsed
+			},
+			status: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &clock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+			s := NewServerWithOptions(&Options{Now: c.Now})
+			defer s.Close()
+
+			var dc struct {
+				DeviceCode string `json:"device_code"`
+				UserCode   string `json:"user_code"`
+			}
+
+			do(t, s, http.MethodPost, "/oauth/device/code", "", map[string]string{"client_id": "id"}, &dc)
+			if tt.setup != nil {
+				tt.setup(s, c, dc.UserCode)
+			}
+
+			code := tt.code
+			if code == "" {
+				code = dc.DeviceCode
+			}
+
+			res := do(t, s, http.MethodPost, "/oauth/device/token", "", map[string]string{"code": code}, nil)
+			if res.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServer_ExchangeToken(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	var tok struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	code := s.AuthorizationCode("sean")
+	body := func(grant, code, refresh string) map[string]string {
+		return map[string]string{
+			"client_id": "id", "client_secret": "secret", "grant_type": grant, "code": code, "refresh_token": refresh,
+		}
+	}
+
+	res := do(t, s, http.MethodPost, "/oauth/token", "", body("authorization_code", code, ""), &tok)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, res.StatusCode)
+	}
+
+	tests := []struct {
+		name   string
+		body   map[string]string
+		status int
+	}{
+		{name: "missing client", body: map[string]string{"grant_type": "authorization_code"}, status: http.StatusUnauthorized},
+		{name: "used code", body: body("authorization_code", code, ""), status: http.StatusUnauthorized},
+		{name: "unknown refresh token", body: body("refresh_token", "", "unknown"), status: http.StatusUnauthorized},
+		{name: "unsupported grant", body: body("password", "", ""), status: http.StatusBadRequest},
+		{name: "refresh", body: body("refresh_token", "", tok.RefreshToken), status: http.StatusOK},
+		{name: "refresh token revoked once used", body: body("refresh_token", "", tok.RefreshToken), status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if res := do(t, s, http.MethodPost, "/oauth/token", "", tt.body, nil); res.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServer_PaginationHeaders(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	for i := 0; i < 25; i++ {
+		s.AddMovie(&Movie{Title: fmt.Sprintf("Movie %d", i), Year: 2000})
+	}
+
+	tests := []struct {
+		query string
+		page  string
+		limit string
+		pages string
+		items int
+	}{
+		{query: "", page: "1", limit: "10", pages: "3", items: 10},
+		{query: "&page=3", page: "3", limit: "10", pages: "3", items: 5},
+		{query: "&page=2&limit=20", page: "2", limit: "20", pages: "2", items: 5},
+		{query: "&page=4", page: "4", limit: "10", pages: "3", items: 0},
+		{query: "&page=0&limit=-1", page: "1", limit: "10", pages: "3", items: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var items []interface{}
+			res := do(t, s, http.MethodGet, "/search/movie?query=movie"+tt.query, "", nil, &items)
+
+			h := res.Header
+			got := []string{
+				h.Get("X-Pagination-Page"), h.Get("X-Pagination-Limit"),
+				h.Get("X-Pagination-Page-Count"), h.Get("X-Pagination-Item-Count"),
+			}
+
+			want := []string{tt.page, tt.limit, tt.pages, "25"}
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("expected headers %v, got %v", want, got)
+			}
+
+			if len(items) != tt.items {
+				t.Errorf("expected %d items, got %d", tt.items, len(items))
+			}
+		})
+	}
+}
+
+func TestServer_EmptyList(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	token := s.Authorize("sean")
+	for _, path := range []string{"/sync/ratings", "/sync/watchlist", "/sync/history", "/search/movie?query=none"} {
+		t.Run(path, func(t *testing.T) {
+			res := do(t, s, http.MethodGet, path, token, nil, nil)
+			if b, _ := ioutil.ReadAll(res.Body); strings.TrimSpace(string(b)) != "[]" {
+				t.Errorf("expected an empty array, got %s", b)
+			}
+		})
+	}
+}
+
+func TestServer_RequestID(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	ids := make(map[string]bool)
+	for _, path := range []string{"/movies/unknown", "/search/movie?query=none", "/sync/history"} {
+		id := do(t, s, http.MethodGet, path, "", nil, nil).Header.Get("X-Request-ID")
+		if id == "" || ids[id] {
+			t.Errorf("expected a unique request id for %s, got %q", path, id)
+		}
+
+		ids[id] = true
+	}
+}
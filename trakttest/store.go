@@ -0,0 +1,532 @@
+package trakttest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// Movie a movie in the catalogue of the server.
+type Movie struct {
+	Title    string
+	Year     int64
+	IDs      trakt.MediaIDs
+	Overview string
+	Runtime  int64
+	// Released the date the movie was released, used for the movie calendars.
+	Released time.Time
+	// DVDReleased the date the movie was released on DVD, used for the DVD calendars.
+	DVDReleased time.Time
+}
+
+// Show a show in the catalogue of the server.
+type Show struct {
+	Title    string
+	Year     int64
+	IDs      trakt.MediaIDs
+	Overview string
+	Runtime  int64
+	Seasons  []*Season
+}
+
+// Season a season of a show.
+type Season struct {
+	Number   int64
+	IDs      trakt.MediaIDs
+	Episodes []*Episode
+}
+
+// Episode an episode of a season.
+type Episode struct {
+	Number int64
+	Title  string
+	IDs    trakt.MediaIDs
+	// FirstAired when the episode first aired, used for the show calendars.
+	FirstAired time.Time
+}
+
+// AddMovie adds a movie to the catalogue. A trakt ID and slug are
+// generated if they are not supplied.
+func (s *Server) AddMovie(m *Movie) *Movie {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.assignIDs(&m.IDs, m.Title, m.Year)
+	s.movies = append(s.movies, m)
+	return m
+}
+
+// AddShow adds a show and its seasons and episodes to the catalogue. A trakt ID and
+// slug are generated for the show, and a trakt ID for each season and episode if
+// they are not supplied.
+func (s *Server) AddShow(sh *Show) *Show {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.assignIDs(&sh.IDs, sh.Title, sh.Year)
+	for _, sn := range sh.Seasons {
+		s.assignIDs(&sn.IDs, "", 0)
+		for _, e := range sn.Episodes {
+			s.assignIDs(&e.IDs, "", 0)
+		}
+	}
+
+	s.shows = append(s.shows, sh)
+	return sh
+}
+
+// Authorize issues a new access token for the user with the supplied username,
+// the user is created if they do not already exist.
+func (s *Server) Authorize(username string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.issueToken(s.getUser(username), s.opts.Now()).access
+}
+
+// state the in-memory state store of the server.
+type state struct {
+	// seq the last generated ID, shared between every type of entity.
+	seq int64
+
+	movies []*Movie
+	shows  []*Show
+
+	users map[string]*user
+	// tokens the issued tokens keyed by their access token.
+	tokens map[string]*token
+	// refresh the issued tokens keyed by their refresh token.
+	refresh map[string]*token
+	// codes the issued authorization codes mapped to the user they were issued to.
+	codes map[string]string
+	// devices the issued device codes keyed by their device code.
+	devices map[string]*deviceCode
+
+	comments []*comment
+}
+
+// newState initialises a new empty state store.
+func newState() *state {
+	return &state{
+		users:   make(map[string]*user),
+		tokens:  make(map[string]*token),
+		refresh: make(map[string]*token),
+		codes:   make(map[string]string),
+		devices: make(map[string]*deviceCode),
+	}
+}
+
+// nextID generates the next sequential ID.
+func (s *state) nextID() int64 {
+	s.seq++
+	return s.seq
+}
+
+// assignIDs generates a trakt ID and slug if they are not defined.
+func (s *state) assignIDs(ids *trakt.MediaIDs, title string, year int64) {
+	if ids.Trakt == 0 {
+		ids.Trakt = trakt.ID(s.nextID())
+	}
+
+	if ids.Slug == "" && title != "" {
+		ids.Slug = trakt.Slug(slugify(title, year))
+	}
+}
+
+// getUser retrieves a user, creating them if they do not exist.
+func (s *state) getUser(username string) *user {
+	if u, ok := s.users[username]; ok {
+		return u
+	}
+
+	u := &user{username: username, activity: make(map[string]time.Time), scrobbled: make(map[string]time.Time)}
+	s.users[username] = u
+	return u
+}
+
+// user a user and their synced data.
+type user struct {
+	username string
+
+	history    []*historyEntry
+	collection []*collected
+	ratings    []*rated
+	watchlist  []*listed
+	playback   []*playback
+	checkin    *checkin
+
+	// scrobbled the last time each item was scrobbled.
+	scrobbled map[string]time.Time
+	// activity the last time each activity was performed,
+	// keyed by the group and field, i.e movies.watched_at
+	activity map[string]time.Time
+}
+
+// touch records that an activity has been performed. Activities are kept
+// strictly increasing so that a change is always visible to clients.
+func (u *user) touch(now time.Time, group, field string) {
+	for _, k := range []string{group + "." + field, "all"} {
+		if prev := u.activity[k]; !now.After(prev) {
+			now = prev.Add(time.Millisecond)
+		}
+	}
+
+	u.activity[group+"."+field] = now
+	u.activity["all"] = now
+}
+
+// json generates the public representation of the user.
+func (u *user) json() map[string]interface{} {
+	return map[string]interface{}{
+		"username": u.username,
+		"private":  false,
+		"name":     u.username,
+		"vip":      false,
+		"vip_ep":   false,
+		"ids":      map[string]interface{}{"slug": u.username},
+	}
+}
+
+// item a reference to a single item in the catalogue.
+type item struct {
+	typ     trakt.Type
+	movie   *Movie
+	show    *Show
+	season  *Season
+	episode *Episode
+}
+
+// key a unique key for the item.
+func (i *item) key() string {
+	return string(i.typ) + ":" + strconv.FormatInt(int64(i.ids().Trakt), 10)
+}
+
+// ids the IDs of the item.
+func (i *item) ids() trakt.MediaIDs {
+	switch i.typ {
+	case trakt.TypeMovie:
+		return i.movie.IDs
+	case trakt.TypeSeason:
+		return i.season.IDs
+	case trakt.TypeEpisode:
+		return i.episode.IDs
+	}
+
+	return i.show.IDs
+}
+
+// group the plural type used to group activities.
+func (i *item) group() string { return i.typ.Plural() }
+
+// json generates the representation of the item which is embedded in responses.
+// This includes the type and the parent show for seasons and episodes.
+func (i *item) json() map[string]interface{} {
+	m := map[string]interface{}{"type": i.typ}
+	switch i.typ {
+	case trakt.TypeMovie:
+		m["movie"] = movieJSON(i.movie)
+		return m
+	case trakt.TypeSeason:
+		m["season"] = seasonJSON(i.season, false)
+	case trakt.TypeEpisode:
+		m["episode"] = episodeJSON(i.season, i.episode)
+	}
+
+	m["show"] = showJSON(i.show)
+	return m
+}
+
+// merge merges the item representation into m.
+func (i *item) merge(m map[string]interface{}) map[string]interface{} {
+	for k, v := range i.json() {
+		m[k] = v
+	}
+
+	return m
+}
+
+// movieJSON generates the representation of a movie.
+func movieJSON(m *Movie) map[string]interface{} {
+	r := map[string]interface{}{
+		"title":    m.Title,
+		"year":     m.Year,
+		"ids":      m.IDs,
+		"overview": m.Overview,
+		"runtime":  m.Runtime,
+	}
+
+	if !m.Released.IsZero() {
+		r["released"] = m.Released.Format("2006-01-02")
+	}
+
+	return r
+}
+
+// showJSON generates the representation of a show.
+func showJSON(sh *Show) map[string]interface{} {
+	return map[string]interface{}{
+		"title":    sh.Title,
+		"year":     sh.Year,
+		"ids":      sh.IDs,
+		"overview": sh.Overview,
+		"runtime":  sh.Runtime,
+	}
+}
+
+// seasonJSON generates the representation of a season, optionally including its episodes.
+func seasonJSON(sn *Season, episodes bool) map[string]interface{} {
+	r := map[string]interface{}{
+		"number":        sn.Number,
+		"ids":           sn.IDs,
+		"episode_count": len(sn.Episodes),
+	}
+
+	if episodes {
+		e := make([]interface{}, 0, len(sn.Episodes))
+		for _, ep := range sn.Episodes {
+			e = append(e, episodeJSON(sn, ep))
+		}
+
+		r["episodes"] = e
+	}
+
+	return r
+}
+
+// episodeJSON generates the representation of an episode.
+func episodeJSON(sn *Season, e *Episode) map[string]interface{} {
+	r := map[string]interface{}{
+		"season": sn.Number,
+		"number": e.Number,
+		"title":  e.Title,
+		"ids":    e.IDs,
+	}
+
+	if !e.FirstAired.IsZero() {
+		r["first_aired"] = formatTime(e.FirstAired)
+	}
+
+	return r
+}
+
+// element an element which identifies an item in a request body, this is the shape
+// used by the sync, checkin, scrobble and comment endpoints.
+type element struct {
+	IDs    trakt.MediaIDs `json:"ids"`
+	Title  string         `json:"title"`
+	Year   int64          `json:"year"`
+	Season int64          `json:"season"`
+	Number int64          `json:"number"`
+	Rating int64          `json:"rating"`
+
+	CollectedAt time.Time `json:"collected_at"`
+	WatchedAt   time.Time `json:"watched_at"`
+	RatedAt     time.Time `json:"rated_at"`
+
+	Seasons  []*element `json:"seasons"`
+	Episodes []*element `json:"episodes"`
+}
+
+// at returns the time supplied on the element, if any.
+func (e *element) at() time.Time {
+	for _, t := range []time.Time{e.CollectedAt, e.WatchedAt, e.RatedAt} {
+		if !t.IsZero() {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// reference the representation of the element when it is reported as not found.
+func (e *element) reference() map[string]interface{} {
+	r := map[string]interface{}{"ids": e.IDs}
+	if e.Title != "" {
+		r["title"] = e.Title
+	}
+
+	if e.Year != 0 {
+		r["year"] = e.Year
+	}
+
+	if e.Number != 0 {
+		r["number"] = e.Number
+	}
+
+	return r
+}
+
+// mediaBody a request body which identifies a single item using its type as the key.
+type mediaBody struct {
+	Movie   *element `json:"movie"`
+	Show    *element `json:"show"`
+	Season  *element `json:"season"`
+	Episode *element `json:"episode"`
+}
+
+// findItem finds the item identified by the body.
+func (s *state) findItem(b *mediaBody) (*item, bool) {
+	switch {
+	case b.Movie != nil:
+		return s.findMovie(b.Movie)
+	case b.Episode != nil && b.Show != nil && b.Episode.IDs == (trakt.MediaIDs{}):
+		sh, ok := s.findShow(b.Show)
+		if !ok {
+			return nil, false
+		}
+
+		return findEpisode(sh, b.Episode.Season, b.Episode.Number)
+	case b.Episode != nil:
+		return s.findEpisode(b.Episode)
+	case b.Season != nil:
+		return s.findSeason(b.Season)
+	case b.Show != nil:
+		return s.findShow(b.Show)
+	}
+
+	return nil, false
+}
+
+// findMovie finds a movie matching the element.
+func (s *state) findMovie(e *element) (*item, bool) {
+	for _, m := range s.movies {
+		if matches(e, m.IDs, m.Title, m.Year) {
+			return &item{typ: trakt.TypeMovie, movie: m}, true
+		}
+	}
+
+	return nil, false
+}
+
+// findShow finds a show matching the element.
+func (s *state) findShow(e *element) (*item, bool) {
+	for _, sh := range s.shows {
+		if matches(e, sh.IDs, sh.Title, sh.Year) {
+			return &item{typ: trakt.TypeShow, show: sh}, true
+		}
+	}
+
+	return nil, false
+}
+
+// findSeason finds a season matching the element.
+func (s *state) findSeason(e *element) (*item, bool) {
+	for _, sh := range s.shows {
+		for _, sn := range sh.Seasons {
+			if matchesIDs(e.IDs, sn.IDs) {
+				return &item{typ: trakt.TypeSeason, show: sh, season: sn}, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// findEpisode finds an episode matching the element.
+func (s *state) findEpisode(e *element) (*item, bool) {
+	for _, sh := range s.shows {
+		for _, sn := range sh.Seasons {
+			for _, ep := range sn.Episodes {
+				if matchesIDs(e.IDs, ep.IDs) {
+					return &item{typ: trakt.TypeEpisode, show: sh, season: sn, episode: ep}, true
+				}
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// findByID finds a movie or show using an ID supplied in a path, this
+// can either be a trakt ID, slug or IMDB ID.
+func (s *state) findByID(typ trakt.Type, id string) (*item, bool) {
+	e := &element{}
+	switch n, err := strconv.ParseInt(id, 10, 64); {
+	case err == nil:
+		e.IDs.Trakt = trakt.ID(n)
+	case strings.HasPrefix(id, "tt"):
+		e.IDs.IMDB = trakt.IMDB(id)
+	default:
+		e.IDs.Slug = trakt.Slug(id)
+	}
+
+	if typ == trakt.TypeMovie {
+		return s.findMovie(e)
+	}
+
+	return s.findShow(e)
+}
+
+// findSeasonNumber finds a season of a show by its number.
+func findSeasonNumber(sh *Show, number int64) (*item, bool) {
+	for _, sn := range sh.Seasons {
+		if sn.Number == number {
+			return &item{typ: trakt.TypeSeason, show: sh, season: sn}, true
+		}
+	}
+
+	return nil, false
+}
+
+// findEpisode finds an episode of a show by its season and number.
+func findEpisode(sh *item, season, number int64) (*item, bool) {
+	sn, ok := findSeasonNumber(sh.show, season)
+	if !ok {
+		return nil, false
+	}
+
+	for _, ep := range sn.season.Episodes {
+		if ep.Number == number {
+			return &item{typ: trakt.TypeEpisode, show: sh.show, season: sn.season, episode: ep}, true
+		}
+	}
+
+	return nil, false
+}
+
+// matches determines if an element matches an item. IDs are matched first,
+// falling back to the title and year if no IDs are supplied.
+func matches(e *element, ids trakt.MediaIDs, title string, year int64) bool {
+	if e.IDs != (trakt.MediaIDs{}) {
+		return matchesIDs(e.IDs, ids)
+	}
+
+	if e.Title == "" || !strings.EqualFold(e.Title, title) {
+		return false
+	}
+
+	return e.Year == 0 || e.Year == year
+}
+
+// matchesIDs determines if any of the supplied IDs match the IDs of an item.
+func matchesIDs(q, ids trakt.MediaIDs) bool {
+	switch {
+	case q.Trakt != 0:
+		return q.Trakt == ids.Trakt
+	case q.IMDB != "":
+		return q.IMDB == ids.IMDB
+	case q.TMDB != 0:
+		return q.TMDB == ids.TMDB
+	case q.TVDB != 0:
+		return q.TVDB == ids.TVDB
+	case q.Slug != "":
+		return q.Slug == ids.Slug
+	}
+
+	return false
+}
+
+// slugify generates a slug from a title and year.
+func slugify(title string, year int64) string {
+	f := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+
+	if year != 0 {
+		f = append(f, strconv.FormatInt(year, 10))
+	}
+
+	return strings.Join(f, "-")
+}
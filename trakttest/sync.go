@@ -0,0 +1,913 @@
+package trakttest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jacklaaa89/trakt"
+)
+
+// historyEntry a single play in a user's history.
+type historyEntry struct {
+	id        int64
+	item      *item
+	action    string
+	watchedAt time.Time
+}
+
+// collected an item in a user's collection.
+type collected struct {
+	item        *item
+	collectedAt time.Time
+	updatedAt   time.Time
+}
+
+// rated an item a user has rated.
+type rated struct {
+	item    *item
+	rating  int64
+	ratedAt time.Time
+}
+
+// listed an item on a user's watchlist.
+type listed struct {
+	id       int64
+	item     *item
+	listedAt time.Time
+}
+
+// playback a paused playback.
+type playback struct {
+	id       int64
+	item     *item
+	progress float64
+	pausedAt time.Time
+}
+
+// syncBody the request body used to add or remove items using the sync endpoints.
+type syncBody struct {
+	Movies   []*element `json:"movies"`
+	Shows    []*element `json:"shows"`
+	Seasons  []*element `json:"seasons"`
+	Episodes []*element `json:"episodes"`
+	IDs      []int64    `json:"ids"`
+}
+
+// target an item which has been resolved from a sync request body along with
+// the time and rating supplied for it, which can be inherited from its parent.
+type target struct {
+	item   *item
+	at     time.Time
+	rating int64
+}
+
+// atOr returns the time supplied for the target, or now if no time was supplied.
+func (t *target) atOr(now time.Time) time.Time {
+	if t.at.IsZero() {
+		return now
+	}
+
+	return t.at
+}
+
+// notFound the set of elements in a request body which could not be found.
+type notFound map[string][]interface{}
+
+// newNotFound initialises an empty set of elements which were not found.
+func newNotFound() notFound {
+	return notFound{
+		"movies":   {},
+		"shows":    {},
+		"seasons":  {},
+		"episodes": {},
+		"people":   {},
+	}
+}
+
+// add adds an element which was not found.
+func (n notFound) add(group string, e *element) { n[group] = append(n[group], e.reference()) }
+
+// counts the number of items affected by a sync request.
+type counts struct {
+	Movies   int64 `json:"movies"`
+	Shows    int64 `json:"shows"`
+	Seasons  int64 `json:"seasons"`
+	Episodes int64 `json:"episodes"`
+}
+
+// add increments the count for the type of the item.
+func (c *counts) add(i *item) {
+	switch i.typ {
+	case trakt.TypeMovie:
+		c.Movies++
+	case trakt.TypeShow:
+		c.Shows++
+	case trakt.TypeSeason:
+		c.Seasons++
+	case trakt.TypeEpisode:
+		c.Episodes++
+	}
+}
+
+// resolve resolves the items in a sync request body. If granular is set, shows and
+// seasons are expanded into their episodes, this is how the collection and history
+// endpoints behave.
+func (s *state) resolve(b *syncBody, granular bool) ([]*target, notFound) {
+	var (
+		targets []*target
+		nf      = newNotFound()
+		root    = &target{}
+	)
+
+	groups := []struct {
+		name     string
+		elements []*element
+		find     func(*element) (*item, bool)
+	}{
+		{"movies", b.Movies, s.findMovie},
+		{"shows", b.Shows, s.findShow},
+		{"seasons", b.Seasons, s.findSeason},
+		{"episodes", b.Episodes, s.findEpisode},
+	}
+
+	for _, g := range groups {
+		for _, e := range g.elements {
+			it, ok := g.find(e)
+			if !ok {
+				nf.add(g.name, e)
+				continue
+			}
+
+			targets = append(targets, expand(it, e, root, granular, nf)...)
+		}
+	}
+
+	return targets, nf
+}
+
+// expand expands an item into the set of targets it refers to.
+func expand(it *item, e *element, parent *target, granular bool, nf notFound) []*target {
+	t := &target{item: it, at: e.at(), rating: e.Rating}
+	if t.at.IsZero() {
+		t.at = parent.at
+	}
+
+	if t.rating == 0 {
+		t.rating = parent.rating
+	}
+
+	var targets []*target
+	switch {
+	case it.typ == trakt.TypeShow && len(e.Seasons) > 0:
+		for _, se := range e.Seasons {
+			sn, ok := findSeasonNumber(it.show, se.Number)
+			if !ok {
+				nf.add("seasons", se)
+				continue
+			}
+
+			targets = append(targets, expand(sn, se, t, granular, nf)...)
+		}
+	case it.typ == trakt.TypeSeason && len(e.Episodes) > 0:
+		for _, ee := range e.Episodes {
+			ep, ok := findEpisode(&item{show: it.show}, it.season.Number, ee.Number)
+			if !ok {
+				nf.add("episodes", ee)
+				continue
+			}
+
+			targets = append(targets, expand(ep, ee, t, granular, nf)...)
+		}
+	case it.typ == trakt.TypeShow && granular:
+		for _, sn := range it.show.Seasons {
+			targets = append(targets, episodes(it.show, sn, t)...)
+		}
+	case it.typ == trakt.TypeSeason && granular:
+		targets = episodes(it.show, it.season, t)
+	default:
+		targets = []*target{t}
+	}
+
+	return targets
+}
+
+// episodes generates a target for each episode in a season.
+func episodes(sh *Show, sn *Season, parent *target) []*target {
+	targets := make([]*target, 0, len(sn.Episodes))
+	for _, ep := range sn.Episodes {
+		it := &item{typ: trakt.TypeEpisode, show: sh, season: sn, episode: ep}
+		targets = append(targets, &target{item: it, at: parent.at, rating: parent.rating})
+	}
+
+	return targets
+}
+
+// decodeSyncBody decodes a sync request body, writing an error response on failure.
+func decodeSyncBody(w http.ResponseWriter, r *request) (*syncBody, bool) {
+	b := &syncBody{}
+	if err := r.decode(b); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return nil, false
+	}
+
+	return b, true
+}
+
+// lastActivities handles GET /sync/last_activities.
+func (s *Server) lastActivities(w http.ResponseWriter, r *request) {
+	u := r.user
+	group := func(name string, fields ...string) map[string]interface{} {
+		m := make(map[string]interface{})
+		for _, f := range fields {
+			m[f] = formatTime(u.activity[name+"."+f])
+		}
+
+		return m
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"all": formatTime(u.activity["all"]),
+		"movies": group("movies", "watched_at", "collected_at", "rated_at",
+			"watchlisted_at", "commented_at", "paused_at", "hidden_at"),
+		"episodes": group("episodes", "watched_at", "collected_at", "rated_at",
+			"watchlisted_at", "commented_at", "paused_at"),
+		"shows":    group("shows", "rated_at", "watchlisted_at", "commented_at", "hidden_at"),
+		"seasons":  group("seasons", "rated_at", "watchlisted_at", "commented_at", "hidden_at"),
+		"comments": group("comments", "liked_at"),
+		"lists":    group("lists", "liked_at", "updated_at", "commented_at"),
+		"account":  group("account", "settings_at"),
+	})
+}
+
+// listPlayback handles GET /sync/playback/:type.
+func (s *Server) listPlayback(w http.ResponseWriter, r *request) {
+	typ, ok := pathType(w, r)
+	if !ok {
+		return
+	}
+
+	pb := make([]*playback, 0, len(r.user.playback))
+	for _, p := range r.user.playback {
+		if typ == trakt.TypeAll || p.item.typ == typ {
+			pb = append(pb, p)
+		}
+	}
+
+	sort.SliceStable(pb, func(i, j int) bool { return pb[i].pausedAt.After(pb[j].pausedAt) })
+	if limit := queryInt(r, "limit", 0); limit > 0 && int64(len(pb)) > limit {
+		pb = pb[:limit]
+	}
+
+	items := make([]interface{}, 0, len(pb))
+	for _, p := range pb {
+		items = append(items, p.json())
+	}
+
+	writeJSON(w, http.StatusOK, items)
+}
+
+// removePlayback handles DELETE /sync/playback/:id.
+func (s *Server) removePlayback(w http.ResponseWriter, r *request) {
+	id, _ := strconv.ParseInt(r.vars["id"], 10, 64)
+	for i, p := range r.user.playback {
+		if p.id == id {
+			r.user.playback = append(r.user.playback[:i], r.user.playback[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "not_found", "playback not found")
+}
+
+// json generates the representation of a playback.
+func (p *playback) json() map[string]interface{} {
+	return p.item.merge(map[string]interface{}{
+		"id":        p.id,
+		"progress":  p.progress,
+		"paused_at": formatTime(p.pausedAt),
+	})
+}
+
+// listCollection handles GET /sync/collection/:type.
+func (s *Server) listCollection(w http.ResponseWriter, r *request) {
+	switch r.vars["type"] {
+	case "movies":
+		items := make([]interface{}, 0)
+		for _, c := range r.user.collection {
+			if c.item.typ != trakt.TypeMovie {
+				continue
+			}
+
+			items = append(items, map[string]interface{}{
+				"collected_at": formatTime(c.collectedAt),
+				"updated_at":   formatTime(c.updatedAt),
+				"movie":        movieJSON(c.item.movie),
+				"metadata":     nil,
+			})
+		}
+
+		writeJSON(w, http.StatusOK, items)
+	case "shows":
+		var entries []*showEntry
+		for _, c := range r.user.collection {
+			if c.item.typ != trakt.TypeEpisode {
+				continue
+			}
+
+			entries = appendShowEntry(entries, c.item, c.collectedAt, c.updatedAt, map[string]interface{}{
+				"number":       c.item.episode.Number,
+				"collected_at": formatTime(c.collectedAt),
+				"metadata":     nil,
+			})
+		}
+
+		items := make([]interface{}, 0, len(entries))
+		for _, e := range entries {
+			items = append(items, map[string]interface{}{
+				"last_collected_at": formatTime(e.last),
+				"last_updated_at":   formatTime(e.updated),
+				"show":              showJSON(e.show),
+				"seasons":           e.seasonsJSON(),
+			})
+		}
+
+		writeJSON(w, http.StatusOK, items)
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "unknown type")
+	}
+}
+
+// addToCollection handles POST /sync/collection.
+func (s *Server) addToCollection(w http.ResponseWriter, r *request) {
+	b, ok := decodeSyncBody(w, r)
+	if !ok {
+		return
+	}
+
+	targets, nf := s.resolve(b, true)
+	added, existing := &counts{}, &counts{}
+	for _, t := range targets {
+		if r.user.findCollected(t.item) != nil {
+			existing.add(t.item)
+			continue
+		}
+
+		r.user.collection = append(r.user.collection, &collected{
+			item:        t.item,
+			collectedAt: t.atOr(r.now),
+			updatedAt:   r.now,
+		})
+
+		added.add(t.item)
+		r.user.touch(r.now, t.item.group(), "collected_at")
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"added":     added,
+		"updated":   &counts{},
+		"existing":  existing,
+		"not_found": nf,
+	})
+}
+
+// removeFromCollection handles POST /sync/collection/remove.
+func (s *Server) removeFromCollection(w http.ResponseWriter, r *request) {
+	b, ok := decodeSyncBody(w, r)
+	if !ok {
+		return
+	}
+
+	targets, nf := s.resolve(b, true)
+	deleted := &counts{}
+	for _, t := range targets {
+		c := r.user.findCollected(t.item)
+		if c == nil {
+			continue
+		}
+
+		r.user.collection = removeCollected(r.user.collection, c)
+		deleted.add(t.item)
+		r.user.touch(r.now, t.item.group(), "collected_at")
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"deleted": deleted, "not_found": nf})
+}
+
+// findCollected finds an item in the user's collection.
+func (u *user) findCollected(i *item) *collected {
+	for _, c := range u.collection {
+		if c.item.key() == i.key() {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// removeCollected removes an entry from a collection.
+func removeCollected(l []*collected, c *collected) []*collected {
+	for i := range l {
+		if l[i] == c {
+			return append(l[:i], l[i+1:]...)
+		}
+	}
+
+	return l
+}
+
+// listWatched handles GET /sync/watched/:type.
+func (s *Server) listWatched(w http.ResponseWriter, r *request) {
+	type plays struct {
+		item  *item
+		plays int64
+		last  time.Time
+	}
+
+	var watched []*plays
+	index := make(map[string]*plays)
+	for _, h := range r.user.history {
+		p, ok := index[h.item.key()]
+		if !ok {
+			p = &plays{item: h.item}
+			index[h.item.key()] = p
+			watched = append(watched, p)
+		}
+
+		p.plays++
+		p.last = latest(p.last, h.watchedAt)
+	}
+
+	switch r.vars["type"] {
+	case "movies":
+		items := make([]interface{}, 0)
+		for _, p := range watched {
+			if p.item.typ != trakt.TypeMovie {
+				continue
+			}
+
+			items = append(items, map[string]interface{}{
+				"plays":           p.plays,
+				"last_watched_at": formatTime(p.last),
+				"last_updated_at": formatTime(p.last),
+				"movie":           movieJSON(p.item.movie),
+			})
+		}
+
+		writeJSON(w, http.StatusOK, items)
+	case "shows":
+		var entries []*showEntry
+		for _, p := range watched {
+			if p.item.typ != trakt.TypeEpisode {
+				continue
+			}
+
+			entries = appendShowEntry(entries, p.item, p.last, p.last, map[string]interface{}{
+				"number":          p.item.episode.Number,
+				"plays":           p.plays,
+				"last_watched_at": formatTime(p.last),
+			})
+		}
+
+		items := make([]interface{}, 0, len(entries))
+		for _, e := range entries {
+			items = append(items, map[string]interface{}{
+				"plays":           e.count,
+				"last_watched_at": formatTime(e.last),
+				"last_updated_at": formatTime(e.updated),
+				"reset_at":        nil,
+				"show":            showJSON(e.show),
+				"seasons":         e.seasonsJSON(),
+			})
+		}
+
+		writeJSON(w, http.StatusOK, items)
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "unknown type")
+	}
+}
+
+// listHistory handles GET /sync/history/:type/:id.
+func (s *Server) listHistory(w http.ResponseWriter, r *request) {
+	typ, ok := pathType(w, r)
+	if !ok {
+		return
+	}
+
+	id, _ := strconv.ParseInt(r.vars["id"], 10, 64)
+	start, _ := time.Parse(time.RFC3339, r.URL.Query().Get("start_at"))
+	end, _ := time.Parse(time.RFC3339, r.URL.Query().Get("end_at"))
+
+	entries := make([]*historyEntry, 0, len(r.user.history))
+	for _, h := range r.user.history {
+		switch {
+		case !matchesType(h.item, typ, id):
+		case !start.IsZero() && h.watchedAt.Before(start):
+		case !end.IsZero() && h.watchedAt.After(end):
+		default:
+			entries = append(entries, h)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].watchedAt.Equal(entries[j].watchedAt) {
+			return entries[i].id > entries[j].id
+		}
+
+		return entries[i].watchedAt.After(entries[j].watchedAt)
+	})
+
+	items := make([]interface{}, 0, len(entries))
+	for _, h := range entries {
+		items = append(items, h.item.merge(map[string]interface{}{
+			"id":         h.id,
+			"watched_at": formatTime(h.watchedAt),
+			"action":     h.action,
+		}))
+	}
+
+	writePage(w, r, items)
+}
+
+// addToHistory handles POST /sync/history.
+func (s *Server) addToHistory(w http.ResponseWriter, r *request) {
+	b, ok := decodeSyncBody(w, r)
+	if !ok {
+		return
+	}
+
+	targets, nf := s.resolve(b, true)
+	added := &counts{}
+	for _, t := range targets {
+		s.watch(r.user, t.item, "watch", t.atOr(r.now), r.now)
+		added.add(t.item)
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"added": added, "not_found": nf})
+}
+
+// watch adds a play to a user's history.
+func (s *state) watch(u *user, i *item, action string, at, now time.Time) *historyEntry {
+	h := &historyEntry{id: s.nextID(), item: i, action: action, watchedAt: at}
+	u.history = append(u.history, h)
+	u.touch(now, i.group(), "watched_at")
+	return h
+}
+
+// removeFromHistory handles POST /sync/history/remove.
+func (s *Server) removeFromHistory(w http.ResponseWriter, r *request) {
+	b, ok := decodeSyncBody(w, r)
+	if !ok {
+		return
+	}
+
+	targets, nf := s.resolve(b, true)
+	remove := make(map[string]bool)
+	for _, t := range targets {
+		remove[t.item.key()] = true
+	}
+
+	ids := make(map[int64]bool)
+	for _, id := range b.IDs {
+		ids[id] = false
+	}
+
+	deleted, kept := &counts{}, r.user.history[:0]
+	for _, h := range r.user.history {
+		_, byID := ids[h.id]
+		if !remove[h.item.key()] && !byID {
+			kept = append(kept, h)
+			continue
+		}
+
+		ids[h.id] = true
+		deleted.add(h.item)
+		r.user.touch(r.now, h.item.group(), "watched_at")
+	}
+
+	r.user.history = kept
+	missing := make([]int64, 0)
+	for _, id := range b.IDs {
+		if !ids[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	nfIDs := make(map[string]interface{})
+	for k, v := range nf {
+		nfIDs[k] = v
+	}
+
+	nfIDs["ids"] = missing
+	writeJSON(w, http.StatusOK, map[string]interface{}{"deleted": deleted, "not_found": nfIDs})
+}
+
+// listRatings handles GET /sync/ratings/:type/:rating.
+func (s *Server) listRatings(w http.ResponseWriter, r *request) {
+	typ, ok := pathType(w, r)
+	if !ok {
+		return
+	}
+
+	filter := make(map[int64]bool)
+	for _, v := range strings.Split(r.vars["rating"], ",") {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			filter[n] = true
+		}
+	}
+
+	ratings := make([]*rated, 0, len(r.user.ratings))
+	for _, rt := range r.user.ratings {
+		if matchesType(rt.item, typ, 0) && (len(filter) == 0 || filter[rt.rating]) {
+			ratings = append(ratings, rt)
+		}
+	}
+
+	sort.SliceStable(ratings, func(i, j int) bool { return ratings[i].ratedAt.After(ratings[j].ratedAt) })
+
+	items := make([]interface{}, 0, len(ratings))
+	for _, rt := range ratings {
+		items = append(items, rt.item.merge(map[string]interface{}{
+			"rating":   rt.rating,
+			"rated_at": formatTime(rt.ratedAt),
+		}))
+	}
+
+	writeOptionalPage(w, r, items)
+}
+
+// addRatings handles POST /sync/ratings.
+func (s *Server) addRatings(w http.ResponseWriter, r *request) {
+	b, ok := decodeSyncBody(w, r)
+	if !ok {
+		return
+	}
+
+	targets, nf := s.resolve(b, false)
+	added := &counts{}
+	for _, t := range targets {
+		if t.rating < 1 || t.rating > 10 {
+			nf.add(t.item.group(), &element{IDs: t.item.ids()})
+			continue
+		}
+
+		rt := r.user.findRating(t.item)
+		if rt == nil {
+			rt = &rated{item: t.item}
+			r.user.ratings = append(r.user.ratings, rt)
+		}
+
+		rt.rating, rt.ratedAt = t.rating, t.atOr(r.now)
+		added.add(t.item)
+		r.user.touch(r.now, t.item.group(), "rated_at")
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"added": added, "not_found": nf})
+}
+
+// removeRatings handles POST /sync/ratings/remove.
+func (s *Server) removeRatings(w http.ResponseWriter, r *request) {
+	b, ok := decodeSyncBody(w, r)
+	if !ok {
+		return
+	}
+
+	targets, nf := s.resolve(b, false)
+	deleted := &counts{}
+	for _, t := range targets {
+		for i, rt := range r.user.ratings {
+			if rt.item.key() != t.item.key() {
+				continue
+			}
+
+			r.user.ratings = append(r.user.ratings[:i], r.user.ratings[i+1:]...)
+			deleted.add(t.item)
+			r.user.touch(r.now, t.item.group(), "rated_at")
+			break
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"deleted": deleted, "not_found": nf})
+}
+
+// findRating finds the user's rating for an item.
+func (u *user) findRating(i *item) *rated {
+	for _, rt := range u.ratings {
+		if rt.item.key() == i.key() {
+			return rt
+		}
+	}
+
+	return nil
+}
+
+// listWatchList handles GET /sync/watchlist/:type/:sort.
+func (s *Server) listWatchList(w http.ResponseWriter, r *request) {
+	typ, ok := pathType(w, r)
+	if !ok {
+		return
+	}
+
+	type entry struct {
+		*listed
+		rank int
+	}
+
+	entries := make([]*entry, 0, len(r.user.watchlist))
+	for i, l := range r.user.watchlist {
+		if matchesType(l.item, typ, 0) {
+			entries = append(entries, &entry{listed: l, rank: i + 1})
+		}
+	}
+
+	applied := r.vars["sort"]
+	switch applied {
+	case "added":
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].listedAt.After(entries[j].listedAt) })
+	case "title":
+		sort.SliceStable(entries, func(i, j int) bool { return title(entries[i].item) < title(entries[j].item) })
+	default:
+		applied = "rank"
+	}
+
+	items := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, e.item.merge(map[string]interface{}{
+			"id":        e.id,
+			"rank":      e.rank,
+			"listed_at": formatTime(e.listedAt),
+			"notes":     nil,
+		}))
+	}
+
+	h := w.Header()
+	h.Set("X-Sort-By", "rank")
+	h.Set("X-Sort-How", "asc")
+	h.Set("X-Applied-Sort-By", applied)
+	h.Set("X-Applied-Sort-How", "asc")
+	writeOptionalPage(w, r, items)
+}
+
+// addToWatchList handles POST /sync/watchlist.
+func (s *Server) addToWatchList(w http.ResponseWriter, r *request) {
+	b, ok := decodeSyncBody(w, r)
+	if !ok {
+		return
+	}
+
+	targets, nf := s.resolve(b, false)
+	added, existing := &counts{}, &counts{}
+	for _, t := range targets {
+		if r.user.findListed(t.item) >= 0 {
+			existing.add(t.item)
+			continue
+		}
+
+		r.user.watchlist = append(r.user.watchlist, &listed{id: s.nextID(), item: t.item, listedAt: r.now})
+		added.add(t.item)
+		r.user.touch(r.now, t.item.group(), "watchlisted_at")
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"added":     added,
+		"existing":  existing,
+		"not_found": nf,
+	})
+}
+
+// removeFromWatchList handles POST /sync/watchlist/remove.
+func (s *Server) removeFromWatchList(w http.ResponseWriter, r *request) {
+	b, ok := decodeSyncBody(w, r)
+	if !ok {
+		return
+	}
+
+	targets, nf := s.resolve(b, false)
+	deleted := &counts{}
+	for _, t := range targets {
+		i := r.user.findListed(t.item)
+		if i < 0 {
+			continue
+		}
+
+		r.user.watchlist = append(r.user.watchlist[:i], r.user.watchlist[i+1:]...)
+		deleted.add(t.item)
+		r.user.touch(r.now, t.item.group(), "watchlisted_at")
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"deleted": deleted, "not_found": nf})
+}
+
+// findListed finds the index of an item on the user's watchlist, -1 is returned if
+// the item is not on the watchlist.
+func (u *user) findListed(i *item) int {
+	for idx, l := range u.watchlist {
+		if l.item.key() == i.key() {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// showEntry groups episodes by their show and season for the collection and watched endpoints.
+type showEntry struct {
+	show    *Show
+	count   int64
+	last    time.Time
+	updated time.Time
+	seasons []int64
+	// episodes the episode representations keyed by their season number.
+	episodes map[int64][]interface{}
+}
+
+// appendShowEntry adds an episode to the entry for its show, creating it if required.
+func appendShowEntry(entries []*showEntry, i *item, last, updated time.Time, episode interface{}) []*showEntry {
+	var e *showEntry
+	for _, se := range entries {
+		if se.show == i.show {
+			e = se
+			break
+		}
+	}
+
+	if e == nil {
+		e = &showEntry{show: i.show, episodes: make(map[int64][]interface{})}
+		entries = append(entries, e)
+	}
+
+	if _, ok := e.episodes[i.season.Number]; !ok {
+		e.seasons = append(e.seasons, i.season.Number)
+	}
+
+	e.count++
+	e.last, e.updated = latest(e.last, last), latest(e.updated, updated)
+	e.episodes[i.season.Number] = append(e.episodes[i.season.Number], episode)
+	return entries
+}
+
+// seasonsJSON generates the representation of the seasons in the entry.
+func (e *showEntry) seasonsJSON() []interface{} {
+	seasons := make([]interface{}, 0, len(e.seasons))
+	for _, n := range e.seasons {
+		seasons = append(seasons, map[string]interface{}{"number": n, "episodes": e.episodes[n]})
+	}
+
+	return seasons
+}
+
+// pathType parses the optional type from the path, writing a not found
+// error if the type is unknown.
+func pathType(w http.ResponseWriter, r *request) (trakt.Type, bool) {
+	switch strings.TrimSuffix(r.vars["type"], "s") {
+	case "", trakt.All:
+		return trakt.TypeAll, true
+	case "movie":
+		return trakt.TypeMovie, true
+	case "show":
+		return trakt.TypeShow, true
+	case "season":
+		return trakt.TypeSeason, true
+	case "episode":
+		return trakt.TypeEpisode, true
+	}
+
+	writeError(w, http.StatusNotFound, "not_found", "unknown type")
+	return "", false
+}
+
+// matchesType determines if an item is of the supplied type. If an ID is supplied the item
+// must also have the ID, the ID of the parent show is matched for seasons and episodes.
+func matchesType(i *item, typ trakt.Type, id int64) bool {
+	switch {
+	case typ == trakt.TypeAll:
+		return id == 0
+	case typ == trakt.TypeShow && (i.typ == trakt.TypeEpisode || i.typ == trakt.TypeShow || i.typ == trakt.TypeSeason):
+		return id == 0 || int64(i.show.IDs.Trakt) == id
+	case i.typ != typ:
+		return false
+	}
+
+	return id == 0 || int64(i.ids().Trakt) == id
+}
+
+// title the title of the movie or show the item belongs to.
+func title(i *item) string {
+	if i.typ == trakt.TypeMovie {
+		return i.movie.Title
+	}
+
+	return i.show.Title
+}
+
+// latest returns the latest of the two times.
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
@@ -19,3 +19,6 @@ func (t *TranslationIterator) Translation() (*Translation, error) {
 	rcv := &Translation{}
 	return rcv, t.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (t *TranslationIterator) Iter() *Iter[*Translation] { return newIter(t, t.Translation) }
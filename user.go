@@ -67,3 +67,6 @@ func (u *UserIterator) User() (*User, error) {
 	rcv := &User{}
 	return rcv, u.Scan(rcv)
 }
+
+// Iter returns the typed variant of the iterator.
+func (u *UserIterator) Iter() *Iter[*User] { return newIter(u, u.User) }